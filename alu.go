@@ -0,0 +1,115 @@
+package main
+
+import "fmt"
+
+// alu8 applies the 8-bit arithmetic/logic operation named by mnemonic
+// (ADD, ADC, SUB, SBC, AND, XOR, OR, CP) to A and value, updating the flags.
+// The result is written back to A for every operation except CP.
+func (cpu *CPU) alu8(mnemonic string, value uint8) uint8 {
+	r := cpu.Registers
+	a := r.getA()
+
+	var carryIn uint8
+	if mnemonic == "ADC" || mnemonic == "SBC" {
+		carryIn = boolToUint8(r.getFlag(CarryFlag))
+	}
+
+	var res uint8
+	switch mnemonic {
+	case "ADD", "ADC":
+		sum := uint16(a) + uint16(value) + uint16(carryIn)
+		res = uint8(sum)
+		r.setFlag(SubtractFlag, false)
+		r.setFlag(HalfCarryFlag, (a&0x0F)+(value&0x0F)+carryIn > 0x0F)
+		r.setFlag(CarryFlag, sum > 0xFF)
+	case "SUB", "SBC", "CP":
+		diff := int16(a) - int16(value) - int16(carryIn)
+		res = uint8(diff)
+		r.setFlag(SubtractFlag, true)
+		r.setFlag(HalfCarryFlag, int16(a&0x0F)-int16(value&0x0F)-int16(carryIn) < 0)
+		r.setFlag(CarryFlag, diff < 0)
+	case "AND":
+		res = a & value
+		r.setFlag(SubtractFlag, false)
+		r.setFlag(HalfCarryFlag, true)
+		r.setFlag(CarryFlag, false)
+	case "XOR":
+		res = a ^ value
+		r.setFlag(SubtractFlag, false)
+		r.setFlag(HalfCarryFlag, false)
+		r.setFlag(CarryFlag, false)
+	case "OR":
+		res = a | value
+		r.setFlag(SubtractFlag, false)
+		r.setFlag(HalfCarryFlag, false)
+		r.setFlag(CarryFlag, false)
+	default:
+		panic(fmt.Sprintf("alu8: Unexpected mnemonic %q", mnemonic))
+	}
+	r.setFlag(ZeroFlag, res == 0)
+
+	if mnemonic != "CP" {
+		r.setA(res)
+	}
+	return res
+}
+
+// addSPE8 computes SP + e8 as done by ADD SP, e8 and LD HL, SP+e8.
+// H and C come from the unsigned addition of the low byte, Z and N are cleared.
+func (cpu *CPU) addSPE8(offset uint8) uint16 {
+	sp := cpu.Registers.getSP()
+	res := uint16(int32(sp) + int32(int8(offset)))
+
+	cpu.Registers.setFlag(ZeroFlag, false)
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, (sp&0x0F)+uint16(offset&0x0F) > 0x0F)
+	cpu.Registers.setFlag(CarryFlag, (sp&0xFF)+uint16(offset) > 0xFF)
+
+	return res
+}
+
+// rotateShift applies the CB-prefixed rotate/shift operation named by mnemonic
+// (RLC, RRC, RL, RR, SLA, SRA, SWAP, SRL) to value and updates the flags.
+func (cpu *CPU) rotateShift(mnemonic string, value uint8) uint8 {
+	r := cpu.Registers
+	carryIn := boolToUint8(r.getFlag(CarryFlag))
+
+	var res uint8
+	var carry bool
+	switch mnemonic {
+	case "RLC":
+		res = (value << 1) | (value >> 7)
+		carry = value&0x80 != 0
+	case "RRC":
+		res = (value >> 1) | (value << 7)
+		carry = value&0x01 != 0
+	case "RL":
+		res = (value << 1) | carryIn
+		carry = value&0x80 != 0
+	case "RR":
+		res = (value >> 1) | (carryIn << 7)
+		carry = value&0x01 != 0
+	case "SLA":
+		res = value << 1
+		carry = value&0x80 != 0
+	case "SRA":
+		// Arithmetic shift keeps bit 7
+		res = (value >> 1) | (value & 0x80)
+		carry = value&0x01 != 0
+	case "SWAP":
+		res = (value << 4) | (value >> 4)
+		carry = false
+	case "SRL":
+		res = value >> 1
+		carry = value&0x01 != 0
+	default:
+		panic(fmt.Sprintf("rotateShift: Unexpected mnemonic %q", mnemonic))
+	}
+
+	r.setFlag(ZeroFlag, res == 0)
+	r.setFlag(SubtractFlag, false)
+	r.setFlag(HalfCarryFlag, false)
+	r.setFlag(CarryFlag, carry)
+
+	return res
+}
@@ -0,0 +1,377 @@
+package main
+
+import "math"
+
+const (
+	nr10Reg = 0xFF10 // Channel 1 sweep
+	nr11Reg = 0xFF11 // Channel 1 duty and length
+	nr12Reg = 0xFF12 // Channel 1 envelope
+	nr13Reg = 0xFF13 // Channel 1 period low
+	nr14Reg = 0xFF14 // Channel 1 period high and control
+	nr21Reg = 0xFF16 // Channel 2 duty and length
+	nr22Reg = 0xFF17 // Channel 2 envelope
+	nr23Reg = 0xFF18 // Channel 2 period low
+	nr24Reg = 0xFF19 // Channel 2 period high and control
+	nr30Reg = 0xFF1A // Channel 3 DAC enable
+	nr31Reg = 0xFF1B // Channel 3 length
+	nr32Reg = 0xFF1C // Channel 3 output level
+	nr33Reg = 0xFF1D // Channel 3 period low
+	nr34Reg = 0xFF1E // Channel 3 period high and control
+	nr41Reg = 0xFF20 // Channel 4 length
+	nr42Reg = 0xFF21 // Channel 4 envelope
+	nr43Reg = 0xFF22 // Channel 4 frequency and randomness
+	nr44Reg = 0xFF23 // Channel 4 control
+	nr50Reg = 0xFF24 // Master volume and VIN panning
+	nr51Reg = 0xFF25 // Sound panning
+	nr52Reg = 0xFF26 // Sound on/off
+
+	waveRAMStart = 0xFF30
+	waveRAMEnd   = 0xFF3F
+)
+
+const (
+	// CPUClock is the number of T-cycles per second at normal speed
+	CPUClock = 4194304
+
+	// DefaultSampleRate is used when no host sample rate is given
+	DefaultSampleRate = 48000
+
+	frameSequencerCycles = CPUClock / 512
+	apuBatchFrames       = 512 // Stereo frames handed to the sink at once
+)
+
+const (
+	nrxTrigger      uint8 = 1 << 7
+	nrxLengthEnable uint8 = 1 << 6
+	nr52Power       uint8 = 1 << 7
+)
+
+// apuReadMasks are ORed into register reads of 0xFF10–0xFF2F: write-only and
+// unused bits read as 1
+var apuReadMasks = [0x20]uint8{
+	0x80, 0x3F, 0x00, 0xFF, 0xBF, // NR10–NR14
+	0xFF, 0x3F, 0x00, 0xFF, 0xBF, // unused, NR21–NR24
+	0x7F, 0xFF, 0x9F, 0xFF, 0xBF, // NR30–NR34
+	0xFF, 0xFF, 0x00, 0x00, 0xBF, // unused, NR41–NR44
+	0x00, 0x00, 0x70, // NR50–NR52
+	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, // unused
+}
+
+// AudioSink consumes the stereo output of the APU
+type AudioSink interface {
+	// WriteSamples receives interleaved left/right samples in [-1, 1]
+	WriteSamples(samples []float32)
+}
+
+// APU is the audio processing unit: two square channels (the first with a
+// frequency sweep), a wave channel and a noise channel, clocked by a 512 Hz
+// frame sequencer, mixed through NR50/NR51 and resampled to the host rate.
+type APU struct {
+	regs [0x17]uint8 // Raw values of 0xFF10–0xFF26 as written
+
+	ch1 squareChannel
+	ch2 squareChannel
+	ch3 waveChannel
+	ch4 noiseChannel
+
+	power     bool
+	cgb       bool // Powering off also clears the length counters
+	seqStep   int
+	seqCycles int
+
+	sink       AudioSink
+	sampleRate int
+	sampleAcc  int // Scaled by sampleRate: one sample is due every CPUClock
+	samples    []float32
+	capLeft    float64 // High-pass filter state, like the output capacitor
+	capRight   float64
+	capCharge  float64
+}
+
+// NewAPU creates an APU that sends samples at sampleRate Hz to sink. The sink
+// may be nil, the registers then behave the same but nothing is mixed.
+func NewAPU(sink AudioSink, sampleRate int) *APU {
+	if sampleRate <= 0 {
+		sampleRate = DefaultSampleRate
+	}
+	a := &APU{sink: sink, sampleRate: sampleRate}
+	a.ch1.hasSweep = true
+	a.ch1.length.max = 64
+	a.ch2.length.max = 64
+	a.ch3.length.max = 256
+	a.ch4.length.max = 64
+	a.capCharge = math.Pow(0.999958, float64(CPUClock)/float64(sampleRate))
+	return a
+}
+
+// Tick advances the channels and the frame sequencer, emitting samples as they fall due
+func (a *APU) Tick(cycles int) {
+	for cycles > 0 {
+		n := min(cycles, frameSequencerCycles-a.seqCycles)
+		if a.sink != nil {
+			n = min(n, a.cyclesToSample())
+		}
+
+		if a.power {
+			a.ch1.tick(n)
+			a.ch2.tick(n)
+			a.ch3.tick(n)
+			a.ch4.tick(n)
+		}
+
+		a.seqCycles += n
+		if a.seqCycles == frameSequencerCycles {
+			a.seqCycles = 0
+			if a.power {
+				a.clockSequencer()
+			}
+		}
+
+		if a.sink != nil {
+			a.sampleAcc += n * a.sampleRate
+			if a.sampleAcc >= CPUClock {
+				a.sampleAcc -= CPUClock
+				a.emitSample()
+			}
+		}
+		cycles -= n
+	}
+}
+
+// cyclesToSample returns the cycles until the next host sample is due
+func (a *APU) cyclesToSample() int {
+	return max(1, (CPUClock-a.sampleAcc+a.sampleRate-1)/a.sampleRate)
+}
+
+// clockSequencer steps the frame sequencer: length at 256 Hz, sweep at 128 Hz
+// and envelopes at 64 Hz
+func (a *APU) clockSequencer() {
+	if a.seqStep%2 == 0 {
+		if a.ch1.length.clock() {
+			a.ch1.enabled = false
+		}
+		if a.ch2.length.clock() {
+			a.ch2.enabled = false
+		}
+		if a.ch3.length.clock() {
+			a.ch3.enabled = false
+		}
+		if a.ch4.length.clock() {
+			a.ch4.enabled = false
+		}
+	}
+	if a.seqStep == 2 || a.seqStep == 6 {
+		a.ch1.clockSweep()
+	}
+	if a.seqStep == 7 {
+		a.ch1.env.clock()
+		a.ch2.env.clock()
+		a.ch4.env.clock()
+	}
+	a.seqStep = (a.seqStep + 1) & 7
+}
+
+// dacOutput converts a digital 0–15 level to [-1, 1], or 0 with the DAC off
+func dacOutput(level uint8, dac bool) float64 {
+	if !dac {
+		return 0
+	}
+	return 1 - float64(level)/7.5
+}
+
+func (a *APU) emitSample() {
+	var left, right float64
+	if a.power {
+		outputs := [4]float64{
+			dacOutput(a.ch1.output(), a.ch1.dac),
+			dacOutput(a.ch2.output(), a.ch2.dac),
+			dacOutput(a.ch3.output(), a.ch3.dac),
+			dacOutput(a.ch4.output(), a.ch4.dac),
+		}
+		nr50, nr51 := a.regs[nr50Reg-nr10Reg], a.regs[nr51Reg-nr10Reg]
+		for i, out := range outputs {
+			if nr51&(1<<(i+4)) != 0 {
+				left += out
+			}
+			if nr51&(1<<i) != 0 {
+				right += out
+			}
+		}
+		left *= float64(nr50>>4&0x07+1) / 8 / 4
+		right *= float64(nr50&0x07+1) / 8 / 4
+	}
+
+	left = a.highPass(left, &a.capLeft)
+	right = a.highPass(right, &a.capRight)
+	a.samples = append(a.samples, float32(left), float32(right))
+	if len(a.samples) >= apuBatchFrames*2 {
+		a.Flush()
+	}
+}
+
+func (a *APU) highPass(in float64, capacitor *float64) float64 {
+	out := in - *capacitor
+	*capacitor = in - out*a.capCharge
+	return out
+}
+
+// Flush hands the samples produced so far to the sink
+func (a *APU) Flush() {
+	if a.sink == nil || len(a.samples) == 0 {
+		return
+	}
+	a.sink.WriteSamples(a.samples)
+	a.samples = a.samples[:0]
+}
+
+func isAPUAddr(addr uint16) bool {
+	return addr >= nr10Reg && addr <= waveRAMEnd
+}
+
+func (a *APU) ReadRegister(addr uint16) uint8 {
+	switch {
+	case addr >= waveRAMStart:
+		return a.ch3.ram[addr-waveRAMStart]
+	case addr == nr52Reg:
+		status := boolToUint8(a.power)<<7 |
+			boolToUint8(a.ch4.enabled)<<3 |
+			boolToUint8(a.ch3.enabled)<<2 |
+			boolToUint8(a.ch2.enabled)<<1 |
+			boolToUint8(a.ch1.enabled)
+		return status | apuReadMasks[addr-nr10Reg]
+	case addr > nr52Reg:
+		return 0xFF
+	}
+	return a.regs[addr-nr10Reg] | apuReadMasks[addr-nr10Reg]
+}
+
+func (a *APU) WriteRegister(addr uint16, value uint8) {
+	switch {
+	case addr >= waveRAMStart:
+		a.ch3.ram[addr-waveRAMStart] = value
+		return
+	case addr == nr52Reg:
+		a.setPower(value&nr52Power != 0)
+		return
+	case addr > nr52Reg:
+		return
+	}
+
+	if !a.power {
+		// Registers are read-only while powered off, except the DMG length counters
+		if a.cgb {
+			return
+		}
+		switch addr {
+		case nr11Reg:
+			a.ch1.length.load(int(value & 0x3F))
+		case nr21Reg:
+			a.ch2.length.load(int(value & 0x3F))
+		case nr31Reg:
+			a.ch3.length.load(int(value))
+		case nr41Reg:
+			a.ch4.length.load(int(value & 0x3F))
+		}
+		return
+	}
+
+	a.regs[addr-nr10Reg] = value
+
+	switch addr {
+	case nr10Reg:
+		a.ch1.sweepPeriod = value >> 4 & 0x07
+		a.ch1.sweepNegate = value&0x08 != 0
+		a.ch1.sweepShift = value & 0x07
+	case nr11Reg:
+		a.ch1.duty = value >> 6
+		a.ch1.length.load(int(value & 0x3F))
+	case nr12Reg:
+		a.ch1.env.write(value)
+		a.ch1.dac = envelopeDAC(value)
+		a.ch1.enabled = a.ch1.enabled && a.ch1.dac
+	case nr13Reg:
+		a.ch1.freq = a.ch1.freq&0x700 | uint16(value)
+	case nr14Reg:
+		a.ch1.freq = a.ch1.freq&0xFF | uint16(value&0x07)<<8
+		a.ch1.length.enabled = value&nrxLengthEnable != 0
+		if value&nrxTrigger != 0 {
+			a.ch1.trigger()
+		}
+
+	case nr21Reg:
+		a.ch2.duty = value >> 6
+		a.ch2.length.load(int(value & 0x3F))
+	case nr22Reg:
+		a.ch2.env.write(value)
+		a.ch2.dac = envelopeDAC(value)
+		a.ch2.enabled = a.ch2.enabled && a.ch2.dac
+	case nr23Reg:
+		a.ch2.freq = a.ch2.freq&0x700 | uint16(value)
+	case nr24Reg:
+		a.ch2.freq = a.ch2.freq&0xFF | uint16(value&0x07)<<8
+		a.ch2.length.enabled = value&nrxLengthEnable != 0
+		if value&nrxTrigger != 0 {
+			a.ch2.trigger()
+		}
+
+	case nr30Reg:
+		a.ch3.dac = value&0x80 != 0
+		a.ch3.enabled = a.ch3.enabled && a.ch3.dac
+	case nr31Reg:
+		a.ch3.length.load(int(value))
+	case nr32Reg:
+		a.ch3.volume = value >> 5 & 0x03
+	case nr33Reg:
+		a.ch3.freq = a.ch3.freq&0x700 | uint16(value)
+	case nr34Reg:
+		a.ch3.freq = a.ch3.freq&0xFF | uint16(value&0x07)<<8
+		a.ch3.length.enabled = value&nrxLengthEnable != 0
+		if value&nrxTrigger != 0 {
+			a.ch3.trigger()
+		}
+
+	case nr41Reg:
+		a.ch4.length.load(int(value & 0x3F))
+	case nr42Reg:
+		a.ch4.env.write(value)
+		a.ch4.dac = envelopeDAC(value)
+		a.ch4.enabled = a.ch4.enabled && a.ch4.dac
+	case nr43Reg:
+		a.ch4.shift = value >> 4
+		a.ch4.width7 = value&0x08 != 0
+		a.ch4.divisor = value & 0x07
+	case nr44Reg:
+		a.ch4.length.enabled = value&nrxLengthEnable != 0
+		if value&nrxTrigger != 0 {
+			a.ch4.trigger()
+		}
+	}
+}
+
+// setPower handles NR52: powering off clears every register but wave RAM, and
+// on DMG the length counters, powering on restarts the frame sequencer
+func (a *APU) setPower(on bool) {
+	if on == a.power {
+		return
+	}
+	a.power = on
+	if on {
+		a.seqStep = 0
+		return
+	}
+
+	a.regs = [len(a.regs)]uint8{}
+	var lengths [4]int
+	if !a.cgb {
+		lengths = [4]int{a.ch1.length.value, a.ch2.length.value, a.ch3.length.value, a.ch4.length.value}
+	}
+	wave := a.ch3.ram
+	a.ch1 = squareChannel{hasSweep: true}
+	a.ch2 = squareChannel{}
+	a.ch3 = waveChannel{ram: wave}
+	a.ch4 = noiseChannel{}
+	a.ch1.length = lengthCounter{value: lengths[0], max: 64}
+	a.ch2.length = lengthCounter{value: lengths[1], max: 64}
+	a.ch3.length = lengthCounter{value: lengths[2], max: 256}
+	a.ch4.length = lengthCounter{value: lengths[3], max: 64}
+}
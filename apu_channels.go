@@ -0,0 +1,265 @@
+package main
+
+// lengthCounter silences a channel once it counts down to zero
+type lengthCounter struct {
+	value   int
+	max     int // 64, or 256 for the wave channel
+	enabled bool
+}
+
+func (l *lengthCounter) load(value int) {
+	l.value = l.max - value
+}
+
+// clock runs at 256 Hz and reports whether the channel must be turned off
+func (l *lengthCounter) clock() bool {
+	if !l.enabled || l.value == 0 {
+		return false
+	}
+	l.value--
+	return l.value == 0
+}
+
+func (l *lengthCounter) trigger() {
+	if l.value == 0 {
+		l.value = l.max
+	}
+}
+
+// envelope ramps the volume of the square and noise channels at 64 Hz
+type envelope struct {
+	initial  uint8
+	increase bool
+	period   uint8
+	volume   uint8
+	timer    uint8
+}
+
+func (e *envelope) write(value uint8) {
+	e.initial = value >> 4
+	e.increase = value&0x08 != 0
+	e.period = value & 0x07
+}
+
+func (e *envelope) trigger() {
+	e.volume = e.initial
+	e.timer = e.period
+}
+
+func (e *envelope) clock() {
+	if e.period == 0 {
+		return
+	}
+	if e.timer > 0 {
+		e.timer--
+	}
+	if e.timer != 0 {
+		return
+	}
+	e.timer = e.period
+	if e.increase && e.volume < 15 {
+		e.volume++
+	} else if !e.increase && e.volume > 0 {
+		e.volume--
+	}
+}
+
+// envelopeDAC reports whether the upper 5 bits of NRx2 power the DAC
+func envelopeDAC(value uint8) bool {
+	return value&0xF8 != 0
+}
+
+var squareDuties = [4][8]uint8{
+	{0, 0, 0, 0, 0, 0, 0, 1}, // 12.5%
+	{1, 0, 0, 0, 0, 0, 0, 1}, // 25%
+	{1, 0, 0, 0, 0, 1, 1, 1}, // 50%
+	{0, 1, 1, 1, 1, 1, 1, 0}, // 75%
+}
+
+// squareChannel is channel 1 (with frequency sweep) or channel 2
+type squareChannel struct {
+	enabled bool
+	dac     bool
+	length  lengthCounter
+	env     envelope
+	duty    uint8
+	step    int
+	freq    uint16 // 11-bit period value
+	timer   int
+
+	hasSweep     bool
+	sweepPeriod  uint8
+	sweepNegate  bool
+	sweepShift   uint8
+	sweepTimer   uint8
+	sweepEnabled bool
+	shadow       uint16
+}
+
+func (c *squareChannel) period() int {
+	return (2048 - int(c.freq)) * 4
+}
+
+func (c *squareChannel) tick(cycles int) {
+	c.timer -= cycles
+	for c.timer <= 0 {
+		c.timer += c.period()
+		c.step = (c.step + 1) & 7
+	}
+}
+
+func (c *squareChannel) trigger() {
+	c.enabled = c.dac
+	c.length.trigger()
+	c.env.trigger()
+	c.timer = c.period()
+
+	if c.hasSweep {
+		c.shadow = c.freq
+		c.sweepTimer = sweepTimerPeriod(c.sweepPeriod)
+		c.sweepEnabled = c.sweepPeriod != 0 || c.sweepShift != 0
+		if c.sweepShift != 0 {
+			c.sweepFrequency()
+		}
+	}
+}
+
+// sweepTimerPeriod treats a period of 0 as 8
+func sweepTimerPeriod(period uint8) uint8 {
+	if period == 0 {
+		return 8
+	}
+	return period
+}
+
+// sweepFrequency computes the next frequency, disabling the channel on overflow
+func (c *squareChannel) sweepFrequency() uint16 {
+	delta := c.shadow >> c.sweepShift
+	next := c.shadow + delta
+	if c.sweepNegate {
+		next = c.shadow - delta
+	}
+	if next > 2047 {
+		c.enabled = false
+	}
+	return next
+}
+
+// clockSweep runs at 128 Hz
+func (c *squareChannel) clockSweep() {
+	if c.sweepTimer > 0 {
+		c.sweepTimer--
+	}
+	if c.sweepTimer != 0 {
+		return
+	}
+	c.sweepTimer = sweepTimerPeriod(c.sweepPeriod)
+	if !c.sweepEnabled || c.sweepPeriod == 0 {
+		return
+	}
+	next := c.sweepFrequency()
+	if next <= 2047 && c.sweepShift != 0 {
+		c.shadow = next
+		c.freq = next
+		// The new value is checked once more for overflow
+		c.sweepFrequency()
+	}
+}
+
+func (c *squareChannel) output() uint8 {
+	if !c.enabled {
+		return 0
+	}
+	return squareDuties[c.duty][c.step] * c.env.volume
+}
+
+// waveChannel plays the 32 4-bit samples of wave RAM
+type waveChannel struct {
+	enabled  bool
+	dac      bool
+	length   lengthCounter
+	volume   uint8 // NR32 output level code
+	freq     uint16
+	timer    int
+	position int
+	ram      [16]uint8
+}
+
+func (c *waveChannel) period() int {
+	return (2048 - int(c.freq)) * 2
+}
+
+func (c *waveChannel) tick(cycles int) {
+	c.timer -= cycles
+	for c.timer <= 0 {
+		c.timer += c.period()
+		c.position = (c.position + 1) & 31
+	}
+}
+
+func (c *waveChannel) trigger() {
+	c.enabled = c.dac
+	c.length.trigger()
+	c.timer = c.period()
+	c.position = 0
+}
+
+// waveVolumeShifts maps the NR32 level code to a right shift: mute, 100%, 50%, 25%
+var waveVolumeShifts = [4]uint8{4, 0, 1, 2}
+
+func (c *waveChannel) output() uint8 {
+	if !c.enabled {
+		return 0
+	}
+	sample := c.ram[c.position/2]
+	if c.position%2 == 0 {
+		sample >>= 4
+	}
+	return (sample & 0x0F) >> waveVolumeShifts[c.volume]
+}
+
+var noiseDivisors = [8]int{8, 16, 32, 48, 64, 80, 96, 112}
+
+// noiseChannel outputs the low bit of a linear feedback shift register
+type noiseChannel struct {
+	enabled bool
+	dac     bool
+	length  lengthCounter
+	env     envelope
+	shift   uint8
+	width7  bool // 7-bit LFSR for a more tonal noise
+	divisor uint8
+	timer   int
+	lfsr    uint16
+}
+
+func (c *noiseChannel) period() int {
+	return noiseDivisors[c.divisor] << c.shift
+}
+
+func (c *noiseChannel) tick(cycles int) {
+	c.timer -= cycles
+	for c.timer <= 0 {
+		c.timer += c.period()
+		feedback := (c.lfsr ^ c.lfsr>>1) & 1
+		c.lfsr = c.lfsr>>1 | feedback<<14
+		if c.width7 {
+			c.lfsr = c.lfsr&^(1<<6) | feedback<<6
+		}
+	}
+}
+
+func (c *noiseChannel) trigger() {
+	c.enabled = c.dac
+	c.length.trigger()
+	c.env.trigger()
+	c.timer = c.period()
+	c.lfsr = 0x7FFF
+}
+
+func (c *noiseChannel) output() uint8 {
+	if !c.enabled || c.lfsr&1 != 0 {
+		return 0
+	}
+	return c.env.volume
+}
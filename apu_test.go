@@ -0,0 +1,120 @@
+package main
+
+import "testing"
+
+func newTestAPU(cgb bool) *APU {
+	a := NewAPU(nil, 0)
+	a.cgb = cgb
+	a.WriteRegister(nr52Reg, nr52Power)
+	return a
+}
+
+func TestAPURegisterReadback(t *testing.T) {
+	a := newTestAPU(false)
+	for addr := uint16(nr10Reg); addr < nr52Reg; addr++ {
+		for _, value := range []uint8{0x00, 0xFF, 0x5A} {
+			a.WriteRegister(addr, value)
+			if got, want := a.ReadRegister(addr), value|apuReadMasks[addr-nr10Reg]; got != want {
+				t.Errorf("%04X: wrote %02X, read %02X, want %02X", addr, value, got, want)
+			}
+		}
+	}
+	for addr := uint16(nr52Reg + 1); addr < waveRAMStart; addr++ {
+		if got := a.ReadRegister(addr); got != 0xFF {
+			t.Errorf("unused %04X reads %02X", addr, got)
+		}
+	}
+	for i := range uint16(16) {
+		a.WriteRegister(waveRAMStart+i, uint8(i*0x11))
+		if got := a.ReadRegister(waveRAMStart + i); got != uint8(i*0x11) {
+			t.Errorf("wave RAM %d reads %02X", i, got)
+		}
+	}
+}
+
+func TestAPUChannelStatus(t *testing.T) {
+	a := newTestAPU(false)
+	if got := a.ReadRegister(nr52Reg); got != 0xF0 {
+		t.Fatalf("NR52 with every channel off reads %02X, want F0", got)
+	}
+
+	a.WriteRegister(nr22Reg, 0xF0) // DAC on
+	a.WriteRegister(nr21Reg, 0x3E) // Two length steps left
+	a.WriteRegister(nr24Reg, nrxTrigger|nrxLengthEnable)
+	a.WriteRegister(nr30Reg, 0x80)
+	a.WriteRegister(nr34Reg, nrxTrigger)
+	if got := a.ReadRegister(nr52Reg); got != 0xF6 {
+		t.Fatalf("NR52 after triggering channels 2 and 3 reads %02X, want F6", got)
+	}
+
+	// Length is clocked on every other sequencer step
+	a.Tick(frameSequencerCycles * 2)
+	if a.ReadRegister(nr52Reg)&0x02 == 0 {
+		t.Error("channel 2 stopped after one length clock")
+	}
+	a.Tick(frameSequencerCycles * 2)
+	if got := a.ReadRegister(nr52Reg); got != 0xF4 {
+		t.Errorf("NR52 after the length ran out reads %02X, want F4", got)
+	}
+
+	a.WriteRegister(nr30Reg, 0x00)
+	if got := a.ReadRegister(nr52Reg); got != 0xF0 {
+		t.Errorf("NR52 after turning the wave DAC off reads %02X, want F0", got)
+	}
+}
+
+func TestAPUPowerOff(t *testing.T) {
+	tests := []struct {
+		name       string
+		cgb        bool
+		wantLength int // Channel 1 length counter after the power cycle
+		wantLoaded int // ... after a length write while powered off
+	}{
+		{"DMG keeps the length counters", false, 1, 64 - 0x20},
+		{"CGB clears the length counters", true, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAPU(tt.cgb)
+			for addr := uint16(nr10Reg); addr < nr52Reg; addr++ {
+				a.WriteRegister(addr, 0xFF)
+			}
+			a.WriteRegister(nr11Reg, 0x3F)
+			a.WriteRegister(waveRAMStart, 0x12)
+
+			a.WriteRegister(nr52Reg, 0x00)
+			if got := a.ReadRegister(nr52Reg); got != 0x70 {
+				t.Errorf("NR52 powered off reads %02X, want 70", got)
+			}
+			for addr := uint16(nr10Reg); addr < nr52Reg; addr++ {
+				if got, want := a.ReadRegister(addr), apuReadMasks[addr-nr10Reg]; got != want {
+					t.Errorf("%04X powered off reads %02X, want cleared %02X", addr, got, want)
+				}
+			}
+			if got := a.ch1.length.value; got != tt.wantLength {
+				t.Errorf("length counter %d after power off, want %d", got, tt.wantLength)
+			}
+
+			// Registers ignore writes while off, wave RAM does not
+			a.WriteRegister(nr12Reg, 0xF0)
+			a.WriteRegister(nr50Reg, 0x77)
+			a.WriteRegister(waveRAMStart+1, 0x34)
+			if a.ReadRegister(nr12Reg) != 0x00 || a.ReadRegister(nr50Reg) != 0x00 {
+				t.Error("register written while powered off")
+			}
+			if a.ReadRegister(waveRAMStart) != 0x12 || a.ReadRegister(waveRAMStart+1) != 0x34 {
+				t.Error("wave RAM lost or read-only while powered off")
+			}
+			a.WriteRegister(nr11Reg, 0x20)
+			if got := a.ch1.length.value; got != tt.wantLoaded {
+				t.Errorf("length counter %d after a write while off, want %d", got, tt.wantLoaded)
+			}
+
+			a.WriteRegister(nr52Reg, nr52Power)
+			a.WriteRegister(nr12Reg, 0xF0)
+			if got := a.ReadRegister(nr12Reg); got != 0xF0 {
+				t.Errorf("NR12 after powering back on reads %02X, want F0", got)
+			}
+		})
+	}
+}
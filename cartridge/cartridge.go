@@ -0,0 +1,102 @@
+// Package cartridge loads Game Boy ROM images and parses their header.
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrTruncated      = errors.New("image truncated")
+	ErrLogoMismatch   = errors.New("nintendo logo mismatch")
+	ErrHeaderChecksum = errors.New("header checksum mismatch")
+	ErrGlobalChecksum = errors.New("global checksum mismatch")
+	ErrInvalidHeader  = errors.New("invalid header field")
+)
+
+// FormatError reports a truncated or malformed ROM image.
+// Err is one of the Err* sentinels and can be matched with errors.Is.
+type FormatError struct {
+	Path   string // File the image was read from, empty for in-memory images
+	Offset int    // Offset of the offending field within the image
+	Err    error
+	Detail string
+}
+
+func (e *FormatError) Error() string {
+	msg := fmt.Sprintf("cartridge: %v at 0x%04X", e.Err, e.Offset)
+	if e.Path != "" {
+		msg = fmt.Sprintf("cartridge %s: %v at 0x%04X", e.Path, e.Err, e.Offset)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// Cartridge is a parsed ROM image
+type Cartridge struct {
+	Path   string
+	Header Header
+	ROM    []byte
+}
+
+// Load reads and validates the ROM image at path (.gb or .gbc)
+func Load(path string) (*Cartridge, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cartridge: %w", err)
+	}
+
+	cart, err := New(data)
+	if err != nil {
+		var fe *FormatError
+		if errors.As(err, &fe) {
+			fe.Path = path
+		}
+		return nil, err
+	}
+	cart.Path = path
+
+	return cart, nil
+}
+
+// New validates an in-memory ROM image.
+// The global checksum is not enforced, as real hardware never checks it; see VerifyGlobalChecksum.
+func New(data []byte) (*Cartridge, error) {
+	header, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < header.ROMSize() {
+		return nil, &FormatError{
+			Offset: len(data),
+			Err:    ErrTruncated,
+			Detail: fmt.Sprintf("image is %d bytes, header declares %d", len(data), header.ROMSize()),
+		}
+	}
+
+	return &Cartridge{
+		Header: header,
+		ROM:    data[:header.ROMSize()],
+	}, nil
+}
+
+// VerifyGlobalChecksum checks the 16-bit sum of the whole image against 0x014E–0x014F
+func (c *Cartridge) VerifyGlobalChecksum() error {
+	if sum := globalChecksum(c.ROM); sum != c.Header.GlobalChecksum {
+		return &FormatError{
+			Path:   c.Path,
+			Offset: globalChecksumAddr,
+			Err:    ErrGlobalChecksum,
+			Detail: fmt.Sprintf("computed 0x%04X, header says 0x%04X", sum, c.Header.GlobalChecksum),
+		}
+	}
+	return nil
+}
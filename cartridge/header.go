@@ -0,0 +1,250 @@
+package cartridge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Header field offsets within the ROM image
+const (
+	logoAddr           = 0x0104
+	titleAddr          = 0x0134
+	manufacturerAddr   = 0x013F
+	cgbFlagAddr        = 0x0143
+	newLicenseeAddr    = 0x0144
+	sgbFlagAddr        = 0x0146
+	typeAddr           = 0x0147
+	romSizeAddr        = 0x0148
+	ramSizeAddr        = 0x0149
+	destinationAddr    = 0x014A
+	oldLicenseeAddr    = 0x014B
+	versionAddr        = 0x014C
+	headerChecksumAddr = 0x014D
+	globalChecksumAddr = 0x014E
+	headerEnd          = 0x0150
+)
+
+const (
+	romBankSize = 0x4000 // 16KB
+
+	maxROMSizeCode     = 0x08 // 8MB
+	useNewLicenseeCode = 0x33
+	cgbFlagSupported   = 0x80
+	cgbFlagOnly        = 0xC0
+	sgbFlagSupported   = 0x03
+
+	titleLength        = 16
+	titleLengthCGB     = 11
+	manufacturerLength = 4
+)
+
+// nintendoLogo is the bitmap the boot ROM compares against 0x0104–0x0133
+var nintendoLogo = [48]byte{
+	0xCE, 0xED, 0x66, 0x66, 0xCC, 0x0D, 0x00, 0x0B, 0x03, 0x73, 0x00, 0x83,
+	0x00, 0x0C, 0x00, 0x0D, 0x00, 0x08, 0x11, 0x1F, 0x88, 0x89, 0x00, 0x0E,
+	0xDC, 0xCC, 0x6E, 0xE6, 0xDD, 0xDD, 0xD9, 0x99, 0xBB, 0xBB, 0x67, 0x63,
+	0x6E, 0x0E, 0xEC, 0xCC, 0xDD, 0xDC, 0x99, 0x9F, 0xBB, 0xB9, 0x33, 0x3E,
+}
+
+// ramSizes maps the 0x0149 code to the external RAM size in bytes
+var ramSizes = map[uint8]int{
+	0x00: 0,
+	0x01: 0x800, // Unofficial 2KB, listed by some homebrew
+	0x02: 0x2000,
+	0x03: 0x8000,
+	0x04: 0x20000,
+	0x05: 0x10000,
+}
+
+// Header is the cartridge header found at 0x0100–0x014F
+type Header struct {
+	Title            string
+	ManufacturerCode string
+	CGBFlag          uint8
+	SGBFlag          uint8
+	Type             Type
+	ROMSizeCode      uint8
+	RAMSizeCode      uint8
+	Destination      uint8
+	OldLicensee      uint8
+	NewLicensee      string
+	Version          uint8
+	HeaderChecksum   uint8
+	GlobalChecksum   uint16
+
+	// The CGB boot ROM colours DMG games from these, see compat palettes
+	TitleChecksum uint8 // Sum of the 16 raw title bytes
+	TitleLetter   uint8 // Raw 4th title byte, tells colliding checksums apart
+}
+
+// parseHeader decodes and validates the header of a ROM image
+func parseHeader(rom []byte) (Header, error) {
+	if len(rom) < headerEnd {
+		return Header{}, &FormatError{
+			Offset: len(rom),
+			Err:    ErrTruncated,
+			Detail: fmt.Sprintf("image is %d bytes, header ends at 0x%04X", len(rom), headerEnd),
+		}
+	}
+
+	if !bytes.Equal(rom[logoAddr:logoAddr+len(nintendoLogo)], nintendoLogo[:]) {
+		return Header{}, &FormatError{Offset: logoAddr, Err: ErrLogoMismatch}
+	}
+
+	h := Header{
+		CGBFlag:        rom[cgbFlagAddr],
+		SGBFlag:        rom[sgbFlagAddr],
+		Type:           Type(rom[typeAddr]),
+		ROMSizeCode:    rom[romSizeAddr],
+		RAMSizeCode:    rom[ramSizeAddr],
+		Destination:    rom[destinationAddr],
+		OldLicensee:    rom[oldLicenseeAddr],
+		NewLicensee:    string(rom[newLicenseeAddr : newLicenseeAddr+2]),
+		Version:        rom[versionAddr],
+		HeaderChecksum: rom[headerChecksumAddr],
+		GlobalChecksum: uint16(rom[globalChecksumAddr])<<8 | uint16(rom[globalChecksumAddr+1]),
+	}
+
+	// On CGB titles the last bytes of the title hold the manufacturer code and CGB flag
+	title := rom[titleAddr : titleAddr+titleLength]
+	if h.CGBFlag&cgbFlagSupported != 0 {
+		title = rom[titleAddr : titleAddr+titleLengthCGB]
+		h.ManufacturerCode = cleanString(rom[manufacturerAddr : manufacturerAddr+manufacturerLength])
+	}
+	h.Title = cleanString(title)
+	for _, b := range rom[titleAddr : titleAddr+titleLength] {
+		h.TitleChecksum += b
+	}
+	h.TitleLetter = rom[titleAddr+3]
+
+	if h.ROMSizeCode > maxROMSizeCode {
+		return Header{}, &FormatError{
+			Offset: romSizeAddr,
+			Err:    ErrInvalidHeader,
+			Detail: fmt.Sprintf("unknown ROM size code 0x%02X", h.ROMSizeCode),
+		}
+	}
+	if _, ok := ramSizes[h.RAMSizeCode]; !ok {
+		return Header{}, &FormatError{
+			Offset: ramSizeAddr,
+			Err:    ErrInvalidHeader,
+			Detail: fmt.Sprintf("unknown RAM size code 0x%02X", h.RAMSizeCode),
+		}
+	}
+
+	if sum := headerChecksum(rom); sum != h.HeaderChecksum {
+		return Header{}, &FormatError{
+			Offset: headerChecksumAddr,
+			Err:    ErrHeaderChecksum,
+			Detail: fmt.Sprintf("computed 0x%02X, header says 0x%02X", sum, h.HeaderChecksum),
+		}
+	}
+
+	return h, nil
+}
+
+// headerChecksum computes the checksum over 0x0134–0x014C as the boot ROM does
+func headerChecksum(rom []byte) uint8 {
+	var sum uint8
+	for _, b := range rom[titleAddr:headerChecksumAddr] {
+		sum = sum - b - 1
+	}
+	return sum
+}
+
+// globalChecksum sums every byte of the image except the checksum itself
+func globalChecksum(rom []byte) uint16 {
+	var sum uint16
+	for i, b := range rom {
+		if i == globalChecksumAddr || i == globalChecksumAddr+1 {
+			continue
+		}
+		sum += uint16(b)
+	}
+	return sum
+}
+
+// cleanString trims the NUL padding and non-printable bytes of header strings
+func cleanString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return -1
+		}
+		return r
+	}, string(b)))
+}
+
+// ROMSize returns the ROM size declared by the header, in bytes
+func (h *Header) ROMSize() int {
+	return (32 * 1024) << h.ROMSizeCode
+}
+
+// ROMBanks returns the number of 16KB ROM banks
+func (h *Header) ROMBanks() int {
+	return h.ROMSize() / romBankSize
+}
+
+// RAMSize returns the external RAM size declared by the header, in bytes
+func (h *Header) RAMSize() int {
+	return ramSizes[h.RAMSizeCode]
+}
+
+// CGBSupported reports whether the game can use CGB features
+func (h *Header) CGBSupported() bool {
+	return h.CGBFlag&cgbFlagSupported != 0
+}
+
+// CGBOnly reports whether the game refuses to run on DMG hardware
+func (h *Header) CGBOnly() bool {
+	return h.CGBFlag == cgbFlagOnly
+}
+
+// SGBSupported reports whether the game uses SGB functions
+func (h *Header) SGBSupported() bool {
+	return h.SGBFlag == sgbFlagSupported && h.OldLicensee == useNewLicenseeCode
+}
+
+// Japanese reports whether the destination code marks a Japanese release
+func (h *Header) Japanese() bool {
+	return h.Destination == 0x00
+}
+
+// LicenseeCode returns the effective publisher code, new-style if the old code defers to it
+func (h *Header) LicenseeCode() string {
+	if h.OldLicensee == useNewLicenseeCode {
+		return h.NewLicensee
+	}
+	return fmt.Sprintf("%02X", h.OldLicensee)
+}
+
+// Licensee returns the publisher name, or its code when unknown
+func (h *Header) Licensee() string {
+	if h.OldLicensee == useNewLicenseeCode {
+		if name, ok := newLicensees[h.NewLicensee]; ok {
+			return name
+		}
+		return h.NewLicensee
+	}
+	if name, ok := oldLicensees[h.OldLicensee]; ok {
+		return name
+	}
+	return h.LicenseeCode()
+}
+
+func (h Header) String() string {
+	return fmt.Sprintf(
+		"%q (%s, ROM %dKB, RAM %dKB, CGB 0x%02X, SGB 0x%02X, licensee %s, v%d)",
+		h.Title,
+		h.Type,
+		h.ROMSize()/1024,
+		h.RAMSize()/1024,
+		h.CGBFlag,
+		h.SGBFlag,
+		h.Licensee(),
+		h.Version,
+	)
+}
@@ -0,0 +1,133 @@
+package cartridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnsupportedMapper = errors.New("unsupported memory bank controller")
+
+const ramBankSize = 0x2000 // 8KB
+
+// MBC is a memory bank controller, mapping the cartridge ROM into 0x0000–0x7FFF
+// and external RAM into 0xA000–0xBFFF. Writes to the ROM area program its registers.
+type MBC interface {
+	ReadByteAt(addr uint16) uint8
+	WriteByteAt(addr uint16, value uint8)
+}
+
+// Battery is implemented by controllers whose RAM (and clock) survive power-off.
+// The data layout matches the .sav files written by other emulators.
+type Battery interface {
+	SaveData() []byte
+	LoadSaveData(data []byte) error
+}
+
+// Option configures a memory bank controller
+type Option func(*options)
+
+type options struct {
+	now      func() time.Time
+	onRumble func(on bool)
+}
+
+// WithTimeSource overrides the wall clock driving cartridge real-time clocks
+func WithTimeSource(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// WithRumble registers a callback invoked when the rumble motor is switched on or off
+func WithRumble(onRumble func(on bool)) Option {
+	return func(o *options) {
+		o.onRumble = onRumble
+	}
+}
+
+// NewMBC returns the memory bank controller declared by the cartridge type byte
+func NewMBC(cart *Cartridge, opts ...Option) (MBC, error) {
+	o := options{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Unknown type bytes would otherwise look like ROM ONLY
+	if !cart.Header.Type.Known() {
+		return nil, fmt.Errorf("cartridge: %w: %s", ErrUnsupportedMapper, cart.Header.Type)
+	}
+
+	switch cart.Header.Type.Mapper() {
+	case MapperNone:
+		return newROMOnly(cart), nil
+	case MapperMBC1:
+		return newMBC1(cart), nil
+	case MapperMBC2:
+		return newMBC2(cart), nil
+	case MapperMBC3:
+		return newMBC3(cart, o.now), nil
+	case MapperMBC5:
+		return newMBC5(cart, o.onRumble), nil
+	default:
+		return nil, fmt.Errorf("cartridge: %w: %s", ErrUnsupportedMapper, cart.Header.Type)
+	}
+}
+
+// romOnly maps a 32KB ROM without any banking, with optional 8KB RAM
+// that is always enabled
+type romOnly struct {
+	rom []byte
+	ram []byte
+}
+
+func newROMOnly(cart *Cartridge) *romOnly {
+	return &romOnly{
+		rom: cart.ROM,
+		ram: make([]byte, cart.Header.RAMSize()),
+	}
+}
+
+func (m *romOnly) ReadByteAt(addr uint16) uint8 {
+	switch {
+	case addr < 0x8000:
+		if int(addr) < len(m.rom) {
+			return m.rom[addr]
+		}
+	case addr >= 0xA000 && addr < 0xC000:
+		if int(addr-0xA000) < len(m.ram) {
+			return m.ram[addr-0xA000]
+		}
+	}
+	return 0xFF
+}
+
+func (m *romOnly) WriteByteAt(addr uint16, value uint8) {
+	if addr >= 0xA000 && addr < 0xC000 && int(addr-0xA000) < len(m.ram) {
+		m.ram[addr-0xA000] = value
+	}
+}
+
+func (m *romOnly) SaveData() []byte {
+	return saveRAM(m.ram)
+}
+
+func (m *romOnly) LoadSaveData(data []byte) error {
+	return loadRAM(m.ram, data)
+}
+
+// romBankOffset returns the image offset of a 16KB bank, wrapped to the ROM size
+func romBankOffset(rom []byte, bank int) int {
+	banks := len(rom) / romBankSize
+	return (bank & (banks - 1)) * romBankSize
+}
+
+// hasLogoAt reports whether a (multicart) game header starts at the given bank
+func hasLogoAt(rom []byte, bank int) bool {
+	offset := bank*romBankSize + logoAddr
+	if offset+len(nintendoLogo) > len(rom) {
+		return false
+	}
+	return bytes.Equal(rom[offset:offset+len(nintendoLogo)], nintendoLogo[:])
+}
@@ -0,0 +1,106 @@
+package cartridge
+
+const (
+	mbc1MulticartSize = 0x100000 // MBC1M carts are 1MB, 4 games of 256KB
+	mbc1MulticartBank = 0x10     // Second game header, used to detect MBC1M wiring
+)
+
+// mbc1 supports up to 2MB ROM and 32KB RAM.
+// The 5-bit BANK1 register selects the ROM bank at 0x4000–0x7FFF and the 2-bit
+// BANK2 register provides either the upper ROM bank bits or the RAM bank.
+type mbc1 struct {
+	rom []byte
+	ram []byte
+
+	ramEnabled bool
+	bank1      uint8 // 0x2000–0x3FFF, 5 bits, 0 is treated as 1
+	bank2      uint8 // 0x4000–0x5FFF, 2 bits
+	mode       uint8 // 0x6000–0x7FFF, 1 selects advanced banking
+
+	// MBC1M multicarts wire BANK1 bit 4 out, so BANK2 lands on ROM A18–A19
+	multicart bool
+}
+
+func newMBC1(cart *Cartridge) *mbc1 {
+	m := &mbc1{
+		rom:   cart.ROM,
+		ram:   make([]byte, cart.Header.RAMSize()),
+		bank1: 1,
+	}
+	m.multicart = len(m.rom) == mbc1MulticartSize && hasLogoAt(m.rom, mbc1MulticartBank)
+	return m
+}
+
+// bank2Shift is the position of BANK2 within the ROM bank number
+func (m *mbc1) bank2Shift() uint {
+	if m.multicart {
+		return 4
+	}
+	return 5
+}
+
+func (m *mbc1) romBank(addr uint16) int {
+	if addr < 0x4000 {
+		// In mode 1, BANK2 also applies to the 0x0000–0x3FFF area (banks 0x00/0x20/0x40/0x60)
+		if m.mode == 0 {
+			return 0
+		}
+		return int(m.bank2) << m.bank2Shift()
+	}
+
+	bank1 := m.bank1
+	if m.multicart {
+		bank1 &= 0x0F
+	}
+	return int(m.bank2)<<m.bank2Shift() | int(bank1)
+}
+
+func (m *mbc1) ramOffset(addr uint16) int {
+	bank := 0
+	if m.mode == 1 {
+		bank = int(m.bank2)
+	}
+	return (bank*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
+
+func (m *mbc1) ReadByteAt(addr uint16) uint8 {
+	switch {
+	case addr < 0x8000:
+		return m.rom[romBankOffset(m.rom, m.romBank(addr))+int(addr&0x3FFF)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled || len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc1) WriteByteAt(addr uint16, value uint8) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnabled = value&0x0F == 0x0A
+	case addr < 0x4000:
+		// The zero check only sees the 5-bit register, hence banks 0x20/0x40/0x60 map to 0x21/0x41/0x61
+		m.bank1 = value & 0x1F
+		if m.bank1 == 0 {
+			m.bank1 = 1
+		}
+	case addr < 0x6000:
+		m.bank2 = value & 0x03
+	case addr < 0x8000:
+		m.mode = value & 0x01
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled && len(m.ram) > 0 {
+			m.ram[m.ramOffset(addr)] = value
+		}
+	}
+}
+
+func (m *mbc1) SaveData() []byte {
+	return saveRAM(m.ram)
+}
+
+func (m *mbc1) LoadSaveData(data []byte) error {
+	return loadRAM(m.ram, data)
+}
@@ -0,0 +1,66 @@
+package cartridge
+
+const mbc2RAMSize = 512 // Built-in 512×4-bit RAM
+
+// mbc2 supports up to 256KB ROM and has built-in 4-bit RAM.
+// Its registers share 0x0000–0x3FFF and are selected by address bit 8.
+type mbc2 struct {
+	rom []byte
+	ram [mbc2RAMSize]uint8
+
+	ramEnabled bool
+	romBank    uint8 // 4 bits, 0 is treated as 1
+}
+
+func newMBC2(cart *Cartridge) *mbc2 {
+	return &mbc2{rom: cart.ROM, romBank: 1}
+}
+
+func (m *mbc2) ReadByteAt(addr uint16) uint8 {
+	switch {
+	case addr < 0x4000:
+		return m.rom[addr]
+	case addr < 0x8000:
+		return m.rom[romBankOffset(m.rom, int(m.romBank))+int(addr&0x3FFF)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return 0xFF
+		}
+		// Only the low 9 address bits are decoded, the upper nibble is open bus
+		return m.ram[addr&0x01FF] | 0xF0
+	}
+	return 0xFF
+}
+
+func (m *mbc2) WriteByteAt(addr uint16, value uint8) {
+	switch {
+	case addr < 0x4000:
+		if addr&0x0100 == 0 {
+			m.ramEnabled = value&0x0F == 0x0A
+			return
+		}
+		m.romBank = value & 0x0F
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled {
+			m.ram[addr&0x01FF] = value & 0x0F
+		}
+	}
+}
+
+func (m *mbc2) SaveData() []byte {
+	return saveRAM(m.ram[:])
+}
+
+func (m *mbc2) LoadSaveData(data []byte) error {
+	if err := loadRAM(m.ram[:], data); err != nil {
+		return err
+	}
+	// Some emulators store the open-bus upper nibble as well
+	for i := range m.ram {
+		m.ram[i] &= 0x0F
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cartridge
+
+import "time"
+
+// mbc3 supports up to 2MB ROM, 32KB RAM and an optional real-time clock.
+// The MBC30 variant (Pokémon Crystal JP) extends this to 4MB ROM and 64KB RAM.
+type mbc3 struct {
+	rom []byte
+	ram []byte
+	rtc *rtc // nil when the cartridge has no timer
+
+	ramEnabled bool // Also gates the RTC registers
+	romBank    uint8
+	ramBank    uint8 // 0x00–0x07 selects RAM, 0x08–0x0C an RTC register
+	romMask    uint8
+	ramMask    uint8 // RAM bank bits wired up: 2 on MBC3, 3 on MBC30
+}
+
+func newMBC3(cart *Cartridge, now func() time.Time) *mbc3 {
+	m := &mbc3{
+		rom:     cart.ROM,
+		ram:     make([]byte, cart.Header.RAMSize()),
+		romBank: 1,
+		romMask: 0x7F,
+		ramMask: 0x03,
+	}
+	if len(m.rom) > 0x200000 {
+		m.romMask = 0xFF
+	}
+	if len(m.ram) > 0x8000 {
+		m.ramMask = 0x07
+	}
+	if cart.Header.Type.HasTimer() {
+		m.rtc = newRTC(now)
+	}
+	return m
+}
+
+func (m *mbc3) ReadByteAt(addr uint16) uint8 {
+	switch {
+	case addr < 0x4000:
+		return m.rom[addr]
+	case addr < 0x8000:
+		return m.rom[romBankOffset(m.rom, int(m.romBank))+int(addr&0x3FFF)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return 0xFF
+		}
+		if m.ramBank >= rtcSeconds {
+			if m.rtc == nil {
+				return 0xFF
+			}
+			return m.rtc.read(m.ramBank)
+		}
+		if len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc3) WriteByteAt(addr uint16, value uint8) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnabled = value&0x0F == 0x0A
+	case addr < 0x4000:
+		m.romBank = value & m.romMask
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case addr < 0x6000:
+		m.ramBank = value & 0x0F
+	case addr < 0x8000:
+		if m.rtc != nil {
+			m.rtc.writeLatch(value)
+		}
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return
+		}
+		if m.ramBank >= rtcSeconds {
+			if m.rtc != nil {
+				m.rtc.write(m.ramBank, value)
+			}
+			return
+		}
+		if len(m.ram) > 0 {
+			m.ram[m.ramOffset(addr)] = value
+		}
+	}
+}
+
+// ramOffset mirrors banks beyond the wired bank bits and the RAM size
+func (m *mbc3) ramOffset(addr uint16) int {
+	return (int(m.ramBank&m.ramMask)*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
+
+// SaveData returns the RAM followed by the RTC trailer when the cartridge has a clock
+func (m *mbc3) SaveData() []byte {
+	data := make([]byte, len(m.ram), len(m.ram)+rtcTrailerSize)
+	copy(data, m.ram)
+	if m.rtc != nil {
+		data = append(data, m.rtc.marshal()...)
+	}
+	return data
+}
+
+// LoadSaveData restores the RAM and, if present, the RTC trailer
+func (m *mbc3) LoadSaveData(data []byte) error {
+	if err := loadRAM(m.ram, data); err != nil {
+		return err
+	}
+
+	trailer := data[len(m.ram):]
+	if len(trailer) == 0 || m.rtc == nil {
+		return nil
+	}
+	return m.rtc.unmarshal(trailer)
+}
@@ -0,0 +1,257 @@
+package cartridge
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// fakeClock is a time source that only moves when told to
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestMBC3(t *testing.T, clock *fakeClock) *mbc3 {
+	t.Helper()
+	mbc, err := NewMBC(testCart(t, 0x10, 0x02, 0x03), WithTimeSource(clock.now))
+	if err != nil {
+		t.Fatalf("NewMBC: %v", err)
+	}
+	m := mbc.(*mbc3)
+	m.WriteByteAt(0x0000, 0x0A)
+	return m
+}
+
+func latch(m *mbc3) {
+	m.WriteByteAt(0x6000, 0x00)
+	m.WriteByteAt(0x6000, 0x01)
+}
+
+// readRTC returns the latched S, M, H, DL and DH registers
+func readRTC(m *mbc3) [5]uint8 {
+	var regs [5]uint8
+	for i := range regs {
+		m.WriteByteAt(0x4000, rtcSeconds+uint8(i))
+		regs[i] = m.ReadByteAt(0xA000)
+	}
+	return regs
+}
+
+func writeRTC(m *mbc3, reg, value uint8) {
+	m.WriteByteAt(0x4000, reg)
+	m.WriteByteAt(0xA000, value)
+}
+
+func TestMBC3RTCLatch(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	m := newTestMBC3(t, clock)
+
+	clock.advance(1*time.Hour + 2*time.Minute + 3*time.Second)
+	if got := readRTC(m); got != [5]uint8{} {
+		t.Fatalf("registers before the first latch = %v, want zero", got)
+	}
+	latch(m)
+	if got, want := readRTC(m), [5]uint8{3, 2, 1, 0, 0}; got != want {
+		t.Fatalf("latched registers = %v, want %v", got, want)
+	}
+
+	clock.advance(10 * time.Second)
+	if got := readRTC(m)[0]; got != 3 {
+		t.Errorf("latched seconds moved to %d without a latch", got)
+	}
+	m.WriteByteAt(0x6000, 0x01)
+	if got := readRTC(m)[0]; got != 3 {
+		t.Errorf("a lone 0x01 write latched, seconds = %d", got)
+	}
+	m.WriteByteAt(0x6000, 0x00)
+	m.WriteByteAt(0x6000, 0x02)
+	m.WriteByteAt(0x6000, 0x01)
+	if got := readRTC(m)[0]; got != 3 {
+		t.Errorf("0x00, 0x02, 0x01 latched, seconds = %d", got)
+	}
+	latch(m)
+	if got := readRTC(m)[0]; got != 13 {
+		t.Errorf("seconds after relatch = %d, want 13", got)
+	}
+}
+
+func TestMBC3RTCHalt(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	m := newTestMBC3(t, clock)
+
+	writeRTC(m, rtcSeconds, 30)
+	writeRTC(m, rtcDaysHigh, rtcHaltFlag)
+	clock.advance(time.Hour)
+	latch(m)
+	if got, want := readRTC(m), [5]uint8{30, 0, 0, 0, rtcHaltFlag}; got != want {
+		t.Fatalf("halted clock = %v, want %v", got, want)
+	}
+
+	writeRTC(m, rtcDaysHigh, 0)
+	clock.advance(5 * time.Second)
+	latch(m)
+	if got, want := readRTC(m), [5]uint8{35, 0, 0, 0, 0}; got != want {
+		t.Errorf("resumed clock = %v, want %v", got, want)
+	}
+}
+
+func TestRTCAdvance(t *testing.T) {
+	tests := []struct {
+		name  string
+		start rtcRegisters
+		secs  int64
+		want  rtcRegisters
+	}{
+		{"second", rtcRegisters{}, 1, rtcRegisters{seconds: 1}},
+		{"minute rollover", rtcRegisters{seconds: 59}, 1, rtcRegisters{minutes: 1}},
+		{"day rollover", rtcRegisters{seconds: 59, minutes: 59, hours: 23}, 1, rtcRegisters{daysLow: 1}},
+		{"day bit 8", rtcRegisters{daysLow: 0xFF}, 86400, rtcRegisters{daysHigh: rtcDayHighBit}},
+		{
+			"day carry",
+			rtcRegisters{seconds: 59, minutes: 59, hours: 23, daysLow: 0xFF, daysHigh: rtcDayHighBit},
+			1,
+			rtcRegisters{daysHigh: rtcCarryFlag},
+		},
+		{"carry is sticky", rtcRegisters{daysHigh: rtcCarryFlag}, 86400, rtcRegisters{daysLow: 1, daysHigh: rtcCarryFlag}},
+		{"carry over many days", rtcRegisters{daysLow: 0xFE, daysHigh: rtcDayHighBit}, 3 * 86400, rtcRegisters{daysLow: 1, daysHigh: rtcCarryFlag}},
+		// Out-of-range values count up to the register width and wrap without carrying
+		{"invalid seconds", rtcRegisters{seconds: 62}, 2, rtcRegisters{seconds: 0}},
+		{"invalid hours", rtcRegisters{hours: 31, minutes: 59, seconds: 59}, 1, rtcRegisters{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regs := tt.start
+			regs.advance(tt.secs)
+			if regs != tt.want {
+				t.Errorf("advance(%d) = %+v, want %+v", tt.secs, regs, tt.want)
+			}
+		})
+	}
+}
+
+func TestMBC3RTCWriteClearsCarry(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	m := newTestMBC3(t, clock)
+
+	writeRTC(m, rtcDaysLow, 0xFF)
+	writeRTC(m, rtcDaysHigh, rtcDayHighBit)
+	clock.advance(24 * time.Hour)
+	latch(m)
+	if got := readRTC(m); got[3] != 0 || got[4] != rtcCarryFlag {
+		t.Fatalf("DL, DH after overflow = %02X, %02X, want 00, %02X", got[3], got[4], rtcCarryFlag)
+	}
+	writeRTC(m, rtcDaysHigh, 0)
+	latch(m)
+	if got := readRTC(m)[4]; got != 0 {
+		t.Errorf("DH after clearing carry = %02X", got)
+	}
+}
+
+func TestMBC3SaveRoundTrip(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	m := newTestMBC3(t, clock)
+	m.WriteByteAt(0x4000, 0x02)
+	m.WriteByteAt(0xA123, 0x5A)
+	writeRTC(m, rtcHours, 5)
+	writeRTC(m, rtcDaysLow, 10)
+	clock.advance(42 * time.Second)
+	latch(m)
+
+	data := m.SaveData()
+	if len(data) != 0x8000+rtcTrailerSize {
+		t.Fatalf("save is %d bytes, want %d", len(data), 0x8000+rtcTrailerSize)
+	}
+	trailer := data[0x8000:]
+	if got := binary.LittleEndian.Uint32(trailer[8:]); got != 5 {
+		t.Errorf("trailer hours = %d, want 5", got)
+	}
+	if got := int64(binary.LittleEndian.Uint64(trailer[40:])); got != clock.t.Unix() {
+		t.Errorf("trailer timestamp = %d, want %d", got, clock.t.Unix())
+	}
+
+	// Load it two days later
+	clock.advance(48 * time.Hour)
+	restored := newTestMBC3(t, clock)
+	if err := restored.LoadSaveData(data); err != nil {
+		t.Fatalf("LoadSaveData: %v", err)
+	}
+	if got, want := readRTC(restored), [5]uint8{42, 0, 5, 10, 0}; got != want {
+		t.Errorf("restored latched registers = %v, want %v", got, want)
+	}
+	latch(restored)
+	if got, want := readRTC(restored), [5]uint8{42, 0, 5, 12, 0}; got != want {
+		t.Errorf("registers two days later = %v, want %v", got, want)
+	}
+	restored.WriteByteAt(0x4000, 0x02)
+	if got := restored.ReadByteAt(0xA123); got != 0x5A {
+		t.Errorf("restored RAM = %02X, want 5A", got)
+	}
+}
+
+func TestMBC3LoadLegacyTrailer(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	saved := clock.t.Add(-90 * time.Second)
+
+	data := make([]byte, 0x8000+rtcTrailerSizeShort)
+	trailer := data[0x8000:]
+	putRegisters(trailer[0:], rtcRegisters{seconds: 10, minutes: 20, hours: 3})
+	putRegisters(trailer[20:], rtcRegisters{seconds: 1})
+	binary.LittleEndian.PutUint32(trailer[40:], uint32(saved.Unix()))
+
+	m := newTestMBC3(t, clock)
+	if err := m.LoadSaveData(data); err != nil {
+		t.Fatalf("LoadSaveData: %v", err)
+	}
+	if got := readRTC(m)[0]; got != 1 {
+		t.Errorf("latched seconds = %d, want 1", got)
+	}
+	latch(m)
+	if got, want := readRTC(m), [5]uint8{40, 21, 3, 0, 0}; got != want {
+		t.Errorf("registers = %v, want %v", got, want)
+	}
+
+	if err := m.LoadSaveData(data[:len(data)-1]); err == nil {
+		t.Error("a 43-byte trailer was accepted")
+	}
+}
+
+func TestMBC3RAMBankMirroring(t *testing.T) {
+	tests := []struct {
+		name    string
+		ramCode uint8
+		bank    uint8
+		mirror  uint8
+	}{
+		{"8KB bank 5", 0x02, 5, 0},
+		{"32KB bank 5", 0x03, 5, 1},
+		{"32KB bank 7", 0x03, 7, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mbc, err := NewMBC(testCart(t, 0x13, 0x02, tt.ramCode))
+			if err != nil {
+				t.Fatalf("NewMBC: %v", err)
+			}
+			mbc.WriteByteAt(0x0000, 0x0A)
+			mbc.WriteByteAt(0x4000, tt.mirror)
+			mbc.WriteByteAt(0xA010, 0x77)
+			mbc.WriteByteAt(0x4000, tt.bank)
+			if got := mbc.ReadByteAt(0xA010); got != 0x77 {
+				t.Errorf("bank %d read %02X, want the bank %d value 77", tt.bank, got, tt.mirror)
+			}
+			mbc.WriteByteAt(0xA011, 0x88)
+			mbc.WriteByteAt(0x4000, tt.mirror)
+			if got := mbc.ReadByteAt(0xA011); got != 0x88 {
+				t.Errorf("write through bank %d read back %02X from bank %d", tt.bank, got, tt.mirror)
+			}
+		})
+	}
+}
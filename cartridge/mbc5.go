@@ -0,0 +1,87 @@
+package cartridge
+
+const mbc5RumbleMotor = 0x08 // Bit 3 of the RAM bank register on rumble carts
+
+// mbc5 supports up to 8MB ROM with a 9-bit bank number and 128KB RAM.
+// Unlike MBC1/MBC3, ROM bank 0 can be mapped at 0x4000–0x7FFF.
+type mbc5 struct {
+	rom []byte
+	ram []byte
+
+	ramEnabled bool
+	romBank    uint16 // 9 bits
+	ramBank    uint8  // 4 bits, 3 on rumble carts
+
+	hasRumble bool
+	rumbleOn  bool
+	onRumble  func(on bool)
+}
+
+func newMBC5(cart *Cartridge, onRumble func(on bool)) *mbc5 {
+	return &mbc5{
+		rom:       cart.ROM,
+		ram:       make([]byte, cart.Header.RAMSize()),
+		romBank:   1,
+		hasRumble: cart.Header.Type.HasRumble(),
+		onRumble:  onRumble,
+	}
+}
+
+func (m *mbc5) ReadByteAt(addr uint16) uint8 {
+	switch {
+	case addr < 0x4000:
+		return m.rom[addr]
+	case addr < 0x8000:
+		return m.rom[romBankOffset(m.rom, int(m.romBank))+int(addr&0x3FFF)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled || len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc5) WriteByteAt(addr uint16, value uint8) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnabled = value&0x0F == 0x0A
+	case addr < 0x3000:
+		m.romBank = m.romBank&0x100 | uint16(value)
+	case addr < 0x4000:
+		m.romBank = m.romBank&0xFF | uint16(value&0x01)<<8
+	case addr < 0x6000:
+		if m.hasRumble {
+			m.setRumble(value&mbc5RumbleMotor != 0)
+			value &^= mbc5RumbleMotor
+		}
+		m.ramBank = value & 0x0F
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled && len(m.ram) > 0 {
+			m.ram[m.ramOffset(addr)] = value
+		}
+	}
+}
+
+func (m *mbc5) ramOffset(addr uint16) int {
+	return (int(m.ramBank)*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
+
+// setRumble notifies the host when the motor changes state
+func (m *mbc5) setRumble(on bool) {
+	if on == m.rumbleOn {
+		return
+	}
+	m.rumbleOn = on
+	if m.onRumble != nil {
+		m.onRumble(on)
+	}
+}
+
+func (m *mbc5) SaveData() []byte {
+	return saveRAM(m.ram)
+}
+
+func (m *mbc5) LoadSaveData(data []byte) error {
+	return loadRAM(m.ram, data)
+}
@@ -0,0 +1,160 @@
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewMBCUnsupported(t *testing.T) {
+	for _, typ := range []Type{0x42, 0x04, 0x20, 0xFD} {
+		if _, err := NewMBC(testCart(t, typ, 0x02, 0x00)); !errors.Is(err, ErrUnsupportedMapper) {
+			t.Errorf("type %02X: err = %v, want ErrUnsupportedMapper", uint8(typ), err)
+		}
+	}
+}
+
+func TestNewMBCPicksController(t *testing.T) {
+	tests := []struct {
+		typ  Type
+		want MBC
+	}{
+		{0x00, &romOnly{}},
+		{0x03, &mbc1{}},
+		{0x06, &mbc2{}},
+		{0x13, &mbc3{}},
+		{0x1E, &mbc5{}},
+	}
+	for _, tt := range tests {
+		mbc, err := NewMBC(testCart(t, tt.typ, 0x02, 0x02))
+		if err != nil {
+			t.Errorf("type %s: %v", tt.typ, err)
+			continue
+		}
+		if got, want := fmt.Sprintf("%T", mbc), fmt.Sprintf("%T", tt.want); got != want {
+			t.Errorf("type %s: controller %s, want %s", tt.typ, got, want)
+		}
+	}
+}
+
+// bankAt returns the number of the ROM bank mapped at addr, as written by testROM
+func bankAt(m MBC, addr uint16) uint8 {
+	return m.ReadByteAt(addr &^ 0x3FFF)
+}
+
+func TestMBC1ROMBanking(t *testing.T) {
+	tests := []struct {
+		name     string
+		romCode  uint8
+		bank1    uint8
+		bank2    uint8
+		mode     uint8
+		wantLow  uint8 // Bank at 0x0000–0x3FFF
+		wantHigh uint8 // Bank at 0x4000–0x7FFF
+	}{
+		{"bank 0 maps to 1", 0x06, 0x00, 0, 0, 0x00, 0x01},
+		{"bank 0x20 maps to 0x21", 0x06, 0x00, 1, 0, 0x00, 0x21},
+		{"bank 0x40 maps to 0x41", 0x06, 0x00, 2, 0, 0x00, 0x41},
+		{"bank 0x60 maps to 0x61", 0x06, 0x20, 3, 0, 0x00, 0x61},
+		{"BANK1 is 5 bits", 0x06, 0xE5, 0, 0, 0x00, 0x05},
+		{"BANK2 upper bits", 0x06, 0x1F, 3, 0, 0x00, 0x7F},
+		{"mode 1 banks 0x0000 with BANK2", 0x06, 0x02, 1, 1, 0x20, 0x22},
+		{"mode 1 bank 0x60", 0x06, 0x01, 3, 1, 0x60, 0x61},
+		{"banks wrap on small ROMs", 0x03, 0x11, 0, 0, 0x00, 0x01},
+		{"BANK2 ignored on 512KB ROMs", 0x04, 0x03, 1, 1, 0x00, 0x03},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMBC1(testCart(t, 0x01, tt.romCode, 0x00))
+			m.WriteByteAt(0x2000, tt.bank1)
+			m.WriteByteAt(0x4000, tt.bank2)
+			m.WriteByteAt(0x6000, tt.mode)
+			if got := bankAt(m, 0x0000); got != tt.wantLow {
+				t.Errorf("0x0000 bank %02X, want %02X", got, tt.wantLow)
+			}
+			if got := bankAt(m, 0x4000); got != tt.wantHigh {
+				t.Errorf("0x4000 bank %02X, want %02X", got, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestMBC1RAM(t *testing.T) {
+	m := newMBC1(testCart(t, 0x03, 0x02, 0x03)) // 32KB RAM, 4 banks
+
+	m.WriteByteAt(0xA000, 0x11)
+	if got := m.ReadByteAt(0xA000); got != 0xFF {
+		t.Errorf("disabled RAM reads %02X, want FF", got)
+	}
+
+	m.WriteByteAt(0x0000, 0x1A) // Only the low nibble counts
+	for bank := range uint8(4) {
+		m.WriteByteAt(0x4000, bank)
+		m.WriteByteAt(0x6000, 1)
+		m.WriteByteAt(0xA000+uint16(bank), 0x40+bank)
+	}
+	for bank := range uint8(4) {
+		m.WriteByteAt(0x4000, bank)
+		if got := m.ReadByteAt(0xA000 + uint16(bank)); got != 0x40+bank {
+			t.Errorf("mode 1 RAM bank %d reads %02X, want %02X", bank, got, 0x40+bank)
+		}
+	}
+
+	// Mode 0 always uses RAM bank 0 whatever BANK2 holds
+	m.WriteByteAt(0x4000, 3)
+	m.WriteByteAt(0x6000, 0)
+	if got, want := m.ReadByteAt(0xA000), uint8(0x40); got != want {
+		t.Errorf("mode 0 reads %02X, want bank 0's %02X", got, want)
+	}
+	if got := m.ReadByteAt(0xA003); got != 0x00 {
+		t.Errorf("mode 0 reads %02X at A003, want bank 0's 00", got)
+	}
+
+	m.WriteByteAt(0x0000, 0x0B)
+	if got := m.ReadByteAt(0xA000); got != 0xFF {
+		t.Errorf("RAM disabled again reads %02X, want FF", got)
+	}
+	m.WriteByteAt(0xA000, 0x99)
+	m.WriteByteAt(0x0000, 0x0A)
+	if got := m.ReadByteAt(0xA000); got != 0x40 {
+		t.Errorf("write while disabled landed: %02X", got)
+	}
+}
+
+func TestMBC1Multicart(t *testing.T) {
+	rom := testROM(0x01, 0x05, 0x00) // 1MB
+	copy(rom[mbc1MulticartBank*romBankSize+logoAddr:], nintendoLogo[:])
+	cart, err := New(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newMBC1(cart)
+	if !m.multicart {
+		t.Fatal("1MB MBC1 with a second logo at bank 0x10 not detected as MBC1M")
+	}
+
+	tests := []struct {
+		bank1, bank2, mode uint8
+		wantLow, wantHigh  uint8
+	}{
+		{0x01, 0, 0, 0x00, 0x01},
+		{0x1F, 0, 0, 0x00, 0x0F}, // BANK1 bit 4 is not wired
+		{0x10, 1, 0, 0x00, 0x10}, // 0x10 is not zero to the MBC, but A18 comes from BANK2
+		{0x02, 1, 0, 0x00, 0x12},
+		{0x02, 3, 1, 0x30, 0x32}, // Each game's bank 0 in mode 1
+	}
+	for _, tt := range tests {
+		m.WriteByteAt(0x2000, tt.bank1)
+		m.WriteByteAt(0x4000, tt.bank2)
+		m.WriteByteAt(0x6000, tt.mode)
+		if low, high := bankAt(m, 0x0000), bankAt(m, 0x4000); low != tt.wantLow || high != tt.wantHigh {
+			t.Errorf("BANK1=%02X BANK2=%d mode %d: banks %02X/%02X, want %02X/%02X",
+				tt.bank1, tt.bank2, tt.mode, low, high, tt.wantLow, tt.wantHigh)
+		}
+	}
+
+	// The same size without a second header is an ordinary MBC1
+	if newMBC1(testCart(t, 0x01, 0x05, 0x00)).multicart {
+		t.Error("plain 1MB MBC1 detected as MBC1M")
+	}
+}
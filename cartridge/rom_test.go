@@ -0,0 +1,173 @@
+package cartridge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testROM builds a valid image with the given type and size codes. Every
+// switchable bank starts with its own bank number so tests can tell them apart.
+func testROM(typ Type, romCode, ramCode uint8) []byte {
+	rom := make([]byte, (32*1024)<<romCode)
+	for bank := 1; bank < len(rom)/romBankSize; bank++ {
+		rom[bank*romBankSize] = uint8(bank)
+	}
+	copy(rom[logoAddr:], nintendoLogo[:])
+	copy(rom[titleAddr:], "TEST")
+	rom[typeAddr] = uint8(typ)
+	rom[romSizeAddr] = romCode
+	rom[ramSizeAddr] = ramCode
+	rom[headerChecksumAddr] = headerChecksum(rom)
+	return rom
+}
+
+func testCart(t *testing.T, typ Type, romCode, ramCode uint8) *Cartridge {
+	t.Helper()
+	cart, err := New(testROM(typ, romCode, ramCode))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return cart
+}
+
+func TestParseHeader(t *testing.T) {
+	rom := testROM(0x13, 0x01, 0x03) // MBC3+RAM+BATTERY, 64KB ROM, 32KB RAM
+	copy(rom[titleAddr:], "POKEMON\x00\x00\x00\x00BXTE")
+	rom[cgbFlagAddr] = cgbFlagSupported
+	rom[oldLicenseeAddr] = useNewLicenseeCode
+	copy(rom[newLicenseeAddr:], "01")
+	rom[versionAddr] = 2
+	rom[headerChecksumAddr] = headerChecksum(rom)
+
+	cart, err := New(rom)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	h := cart.Header
+	if h.Title != "POKEMON" || h.ManufacturerCode != "BXTE" {
+		t.Errorf("title %q manufacturer %q, want POKEMON and BXTE", h.Title, h.ManufacturerCode)
+	}
+	if h.Type != 0x13 || h.ROMSize() != 64*1024 || h.ROMBanks() != 4 || h.RAMSize() != 32*1024 {
+		t.Errorf("type %s ROM %d (%d banks) RAM %d", h.Type, h.ROMSize(), h.ROMBanks(), h.RAMSize())
+	}
+	if !h.CGBSupported() || h.LicenseeCode() != "01" || h.Version != 2 {
+		t.Errorf("CGB %v licensee %q version %d", h.CGBSupported(), h.LicenseeCode(), h.Version)
+	}
+	if len(cart.ROM) != 64*1024 {
+		t.Errorf("ROM is %d bytes, want the declared 64KB", len(cart.ROM))
+	}
+
+	// Without the CGB flag the whole 16 bytes are title
+	rom[cgbFlagAddr] = 0
+	copy(rom[titleAddr:], "SIXTEEN BYTE TTL")
+	rom[headerChecksumAddr] = headerChecksum(rom)
+	if cart, err = New(rom); err != nil || cart.Header.Title != "SIXTEEN BYTE TTL" {
+		t.Errorf("DMG title %q, err %v", cart.Header.Title, err)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		corrupt    func(rom []byte) []byte
+		wantErr    error
+		wantOffset int
+	}{
+		{"header truncated", func(rom []byte) []byte { return rom[:0x0140] }, ErrTruncated, 0x0140},
+		{"empty image", func(rom []byte) []byte { return nil }, ErrTruncated, 0},
+		{"image shorter than declared", func(rom []byte) []byte { return rom[:0x6000] }, ErrTruncated, 0x6000},
+		{"logo mismatch", func(rom []byte) []byte {
+			rom[logoAddr+10] ^= 0xFF
+			return rom
+		}, ErrLogoMismatch, logoAddr},
+		{"unknown ROM size", func(rom []byte) []byte {
+			rom[romSizeAddr] = 0x09
+			rom[headerChecksumAddr] = headerChecksum(rom)
+			return rom
+		}, ErrInvalidHeader, romSizeAddr},
+		{"unknown RAM size", func(rom []byte) []byte {
+			rom[ramSizeAddr] = 0x06
+			rom[headerChecksumAddr] = headerChecksum(rom)
+			return rom
+		}, ErrInvalidHeader, ramSizeAddr},
+		{"header checksum", func(rom []byte) []byte {
+			rom[headerChecksumAddr]++
+			return rom
+		}, ErrHeaderChecksum, headerChecksumAddr},
+		{"title changed after the checksum", func(rom []byte) []byte {
+			rom[titleAddr] = 'X'
+			return rom
+		}, ErrHeaderChecksum, headerChecksumAddr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.corrupt(testROM(0x00, 0x01, 0x00)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New: %v, want %v", err, tt.wantErr)
+			}
+			var fe *FormatError
+			if !errors.As(err, &fe) {
+				t.Fatalf("New: %T is not a *FormatError", err)
+			}
+			if fe.Offset != tt.wantOffset || fe.Path != "" {
+				t.Errorf("offset 0x%04X path %q, want 0x%04X and no path", fe.Offset, fe.Path, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.gb")
+	bad := filepath.Join(dir, "bad.gb")
+	rom := testROM(0x00, 0x00, 0x00)
+	if err := os.WriteFile(good, rom, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bad, rom[:0x0100], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cart, err := Load(good)
+	if err != nil || cart.Path != good {
+		t.Fatalf("Load: %v, path %q", err, cart.Path)
+	}
+
+	_, err = Load(bad)
+	var fe *FormatError
+	if !errors.As(err, &fe) || fe.Path != bad || !errors.Is(err, ErrTruncated) {
+		t.Errorf("Load of a truncated file: %v, want a truncated FormatError for %s", err, bad)
+	}
+	if !strings.Contains(err.Error(), bad) {
+		t.Errorf("error %q does not name the file", err)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.gb")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load of a missing file: %v", err)
+	}
+}
+
+func TestVerifyGlobalChecksum(t *testing.T) {
+	rom := testROM(0x00, 0x00, 0x00)
+	cart, err := New(rom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cart.VerifyGlobalChecksum()
+	var fe *FormatError
+	if !errors.As(err, &fe) || !errors.Is(err, ErrGlobalChecksum) || fe.Offset != globalChecksumAddr {
+		t.Fatalf("zero global checksum: %v, want a global checksum FormatError", err)
+	}
+
+	sum := globalChecksum(rom)
+	rom[globalChecksumAddr], rom[globalChecksumAddr+1] = uint8(sum>>8), uint8(sum)
+	if cart, err = New(rom); err != nil {
+		t.Fatal(err)
+	}
+	if err := cart.VerifyGlobalChecksum(); err != nil {
+		t.Errorf("fixed global checksum: %v", err)
+	}
+}
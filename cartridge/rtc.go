@@ -0,0 +1,237 @@
+package cartridge
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// RTC register selectors, written to 0x4000–0x5FFF
+const (
+	rtcSeconds  = 0x08
+	rtcMinutes  = 0x09
+	rtcHours    = 0x0A
+	rtcDaysLow  = 0x0B
+	rtcDaysHigh = 0x0C
+)
+
+// Flags of the DH register
+const (
+	rtcDayHighBit uint8 = 1 << 0 // Bit 8 of the day counter
+	rtcHaltFlag   uint8 = 1 << 6
+	rtcCarryFlag  uint8 = 1 << 7 // Day counter overflowed past 511
+)
+
+// Save trailer, as written after the RAM by BGB, VBA-M and others:
+// 5 current registers and 5 latched registers as little-endian uint32,
+// followed by the UNIX timestamp of the save as uint64 (or uint32 in the older 44-byte variant)
+const (
+	rtcTrailerSize      = 48
+	rtcTrailerSizeShort = 44
+)
+
+type rtcRegisters struct {
+	seconds  uint8
+	minutes  uint8
+	hours    uint8
+	daysLow  uint8
+	daysHigh uint8
+}
+
+// rtc is the MBC3 real-time clock. Registers advance lazily from the time
+// source whenever they are accessed.
+type rtc struct {
+	now     func() time.Time
+	base    time.Time // Wall-clock time matching the current register values
+	current rtcRegisters
+	latched rtcRegisters
+
+	latchArmed bool // 0x00 was written, a following 0x01 latches
+}
+
+func newRTC(now func() time.Time) *rtc {
+	return &rtc{now: now, base: now()}
+}
+
+func (r *rtcRegisters) days() int {
+	return int(r.daysHigh&rtcDayHighBit)<<8 | int(r.daysLow)
+}
+
+func (r *rtcRegisters) setDays(days int) {
+	r.daysLow = uint8(days)
+	r.daysHigh = r.daysHigh&^rtcDayHighBit | uint8(days>>8)&rtcDayHighBit
+}
+
+// tick advances the counters by one second. The registers wrap at their bit
+// width, so out-of-range values written by software roll over without carry.
+func (r *rtcRegisters) tick() {
+	r.seconds = (r.seconds + 1) & 0x3F
+	if r.seconds != 60 {
+		return
+	}
+	r.seconds = 0
+	r.minutes = (r.minutes + 1) & 0x3F
+	if r.minutes != 60 {
+		return
+	}
+	r.minutes = 0
+	r.hours = (r.hours + 1) & 0x1F
+	if r.hours != 24 {
+		return
+	}
+	r.hours = 0
+	r.addDays(1)
+}
+
+func (r *rtcRegisters) addDays(n int) {
+	days := r.days() + n
+	if days > 0x1FF {
+		r.daysHigh |= rtcCarryFlag
+		days &= 0x1FF
+	}
+	r.setDays(days)
+}
+
+func (r *rtcRegisters) valid() bool {
+	return r.seconds < 60 && r.minutes < 60 && r.hours < 24
+}
+
+// advance moves the counters forward by secs seconds
+func (r *rtcRegisters) advance(secs int64) {
+	// Step through invalid values one second at a time, as the hardware would
+	for secs > 0 && !r.valid() {
+		r.tick()
+		secs--
+	}
+	if secs == 0 {
+		return
+	}
+
+	total := secs + int64(r.seconds) + 60*int64(r.minutes) + 3600*int64(r.hours)
+	r.seconds = uint8(total % 60)
+	total /= 60
+	r.minutes = uint8(total % 60)
+	total /= 60
+	r.hours = uint8(total % 24)
+	total /= 24
+
+	days := int64(r.days()) + total
+	if days > 0x1FF {
+		r.daysHigh |= rtcCarryFlag
+		days &= 0x1FF
+	}
+	r.setDays(int(days))
+}
+
+// update catches the counters up with the time source
+func (r *rtc) update() {
+	now := r.now()
+	if r.current.daysHigh&rtcHaltFlag != 0 {
+		r.base = now
+		return
+	}
+	secs := int64(now.Sub(r.base) / time.Second)
+	if secs <= 0 {
+		return
+	}
+	r.current.advance(secs)
+	r.base = r.base.Add(time.Duration(secs) * time.Second)
+}
+
+// writeLatch handles writes to 0x6000–0x7FFF, latching on a 0x00 → 0x01 sequence
+func (r *rtc) writeLatch(value uint8) {
+	if r.latchArmed && value == 0x01 {
+		r.update()
+		r.latched = r.current
+	}
+	r.latchArmed = value == 0x00
+}
+
+func (r *rtc) read(reg uint8) uint8 {
+	switch reg {
+	case rtcSeconds:
+		return r.latched.seconds & 0x3F
+	case rtcMinutes:
+		return r.latched.minutes & 0x3F
+	case rtcHours:
+		return r.latched.hours & 0x1F
+	case rtcDaysLow:
+		return r.latched.daysLow
+	case rtcDaysHigh:
+		return r.latched.daysHigh & (rtcDayHighBit | rtcHaltFlag | rtcCarryFlag)
+	}
+	return 0xFF
+}
+
+func (r *rtc) write(reg uint8, value uint8) {
+	r.update()
+	switch reg {
+	case rtcSeconds:
+		r.current.seconds = value & 0x3F
+		// Writing the seconds resets the sub-second divider
+		r.base = r.now()
+	case rtcMinutes:
+		r.current.minutes = value & 0x3F
+	case rtcHours:
+		r.current.hours = value & 0x1F
+	case rtcDaysLow:
+		r.current.daysLow = value
+	case rtcDaysHigh:
+		wasHalted := r.current.daysHigh&rtcHaltFlag != 0
+		r.current.daysHigh = value & (rtcDayHighBit | rtcHaltFlag | rtcCarryFlag)
+		if wasHalted && value&rtcHaltFlag == 0 {
+			// Resume counting from now
+			r.base = r.now()
+		}
+	}
+}
+
+func putRegisters(b []byte, regs rtcRegisters) {
+	binary.LittleEndian.PutUint32(b[0:], uint32(regs.seconds))
+	binary.LittleEndian.PutUint32(b[4:], uint32(regs.minutes))
+	binary.LittleEndian.PutUint32(b[8:], uint32(regs.hours))
+	binary.LittleEndian.PutUint32(b[12:], uint32(regs.daysLow))
+	binary.LittleEndian.PutUint32(b[16:], uint32(regs.daysHigh))
+}
+
+func getRegisters(b []byte) rtcRegisters {
+	return rtcRegisters{
+		seconds:  uint8(binary.LittleEndian.Uint32(b[0:])),
+		minutes:  uint8(binary.LittleEndian.Uint32(b[4:])),
+		hours:    uint8(binary.LittleEndian.Uint32(b[8:])),
+		daysLow:  uint8(binary.LittleEndian.Uint32(b[12:])),
+		daysHigh: uint8(binary.LittleEndian.Uint32(b[16:])),
+	}
+}
+
+// marshal encodes the clock as the 48-byte save trailer
+func (r *rtc) marshal() []byte {
+	r.update()
+	b := make([]byte, rtcTrailerSize)
+	putRegisters(b[0:], r.current)
+	putRegisters(b[20:], r.latched)
+	binary.LittleEndian.PutUint64(b[40:], uint64(r.base.Unix()))
+	return b
+}
+
+// unmarshal restores the clock from a 44 or 48-byte save trailer.
+// Time elapsed since the save is applied on the next access.
+func (r *rtc) unmarshal(b []byte) error {
+	var timestamp int64
+	switch len(b) {
+	case rtcTrailerSize:
+		timestamp = int64(binary.LittleEndian.Uint64(b[40:]))
+	case rtcTrailerSizeShort:
+		timestamp = int64(binary.LittleEndian.Uint32(b[40:]))
+	default:
+		return fmt.Errorf("RTC trailer is %d bytes, expected %d or %d", len(b), rtcTrailerSize, rtcTrailerSizeShort)
+	}
+
+	r.current = getRegisters(b[0:])
+	r.latched = getRegisters(b[20:])
+	r.base = time.Unix(timestamp, 0)
+	if r.current.daysHigh&rtcHaltFlag != 0 {
+		r.base = r.now()
+	}
+	return nil
+}
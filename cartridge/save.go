@@ -0,0 +1,102 @@
+package cartridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SavePath returns the .sav file sitting next to the ROM image
+func SavePath(romPath string) string {
+	return strings.TrimSuffix(romPath, filepath.Ext(romPath)) + ".sav"
+}
+
+// Saver persists the battery-backed state of a controller to a .sav file
+type Saver struct {
+	path    string
+	battery Battery
+	last    []byte // Contents of the last load or flush, to skip unchanged writes
+}
+
+func NewSaver(path string, battery Battery) *Saver {
+	return &Saver{path: path, battery: battery}
+}
+
+func (s *Saver) Path() string {
+	return s.path
+}
+
+// Load restores the save file if it exists. A missing file is not an error.
+func (s *Saver) Load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.last = s.battery.SaveData()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cartridge: %w", err)
+	}
+	if err := s.battery.LoadSaveData(data); err != nil {
+		return fmt.Errorf("cartridge %s: %w", s.path, err)
+	}
+	s.last = s.battery.SaveData()
+	return nil
+}
+
+// Flush writes the save file if its contents changed since the last load or flush
+func (s *Saver) Flush() error {
+	data := s.battery.SaveData()
+	if s.last != nil && bytes.Equal(data, s.last) {
+		return nil
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("cartridge: %w", err)
+	}
+	s.last = data
+	return nil
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames
+// it over path, so a crash never leaves a truncated file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	// CreateTemp uses 0600, save files are not secret
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// saveRAM and loadRAM implement Battery for controllers whose save is the raw RAM
+func saveRAM(ram []byte) []byte {
+	return bytes.Clone(ram)
+}
+
+func loadRAM(ram []byte, data []byte) error {
+	if len(data) < len(ram) {
+		return fmt.Errorf("save is %d bytes, expected %d", len(data), len(ram))
+	}
+	copy(ram, data)
+	return nil
+}
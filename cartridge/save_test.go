@@ -0,0 +1,146 @@
+package cartridge
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestSaver returns an 8KB RAM MBC1 battery saved to game.sav in a fresh directory
+func newTestSaver(t *testing.T) (*Saver, *mbc1, string) {
+	t.Helper()
+	dir := t.TempDir()
+	m := newMBC1(testCart(t, 0x03, 0x00, 0x02))
+	return NewSaver(SavePath(filepath.Join(dir, "game.gb")), m), m, dir
+}
+
+// dirEntries lists the file names in dir
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestSavePath(t *testing.T) {
+	for rom, want := range map[string]string{
+		"games/tetris.gb": "games/tetris.sav",
+		"games/zelda.gbc": "games/zelda.sav",
+		"games/no-ext":    "games/no-ext.sav",
+		"games/v1.1/p.gb": "games/v1.1/p.sav",
+	} {
+		if got := SavePath(rom); got != want {
+			t.Errorf("SavePath(%q) = %q, want %q", rom, got, want)
+		}
+	}
+}
+
+func TestSaverFlush(t *testing.T) {
+	s, m, dir := newTestSaver(t)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load without a .sav: %v", err)
+	}
+	if names := dirEntries(t, dir); len(names) != 0 {
+		t.Fatalf("Load created %v", names)
+	}
+
+	// Nothing changed since the load, nothing is written
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if names := dirEntries(t, dir); len(names) != 0 {
+		t.Fatalf("unchanged flush wrote %v", names)
+	}
+
+	for i := range m.ram {
+		m.ram[i] = uint8(i * 7)
+	}
+	for range 2 {
+		if err := s.Flush(); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+		if names := dirEntries(t, dir); len(names) != 1 || names[0] != "game.sav" {
+			t.Fatalf("directory holds %v, want only game.sav", names)
+		}
+		data, err := os.ReadFile(s.Path())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, m.ram) {
+			t.Fatal(".sav does not match the RAM byte for byte")
+		}
+		m.ram[100] ^= 0xFF // The second flush replaces an existing file
+	}
+	if info, err := os.Stat(s.Path()); err != nil || info.Mode().Perm() != 0o644 {
+		t.Errorf("save file mode %v, err %v, want 0644", info.Mode().Perm(), err)
+	}
+}
+
+func TestSaverFlushError(t *testing.T) {
+	s, m, dir := newTestSaver(t)
+	s.path = filepath.Join(dir, "missing", "game.sav")
+	m.ram[0] = 1
+	if err := s.Flush(); err == nil {
+		t.Fatal("Flush into a missing directory succeeded")
+	}
+	if names := dirEntries(t, dir); len(names) != 0 {
+		t.Errorf("failed flush left %v behind", names)
+	}
+}
+
+func TestSaverLoad(t *testing.T) {
+	ram := make([]byte, 0x2000)
+	for i := range ram {
+		ram[i] = uint8(i)
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"exact size", ram, false},
+		{"longer file, the tail is ignored", append(bytes.Clone(ram), 1, 2, 3, 4), false},
+		{"short file", ram[:0x1000], true},
+		{"empty file", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m, _ := newTestSaver(t)
+			if err := os.WriteFile(s.Path(), tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := s.Load()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), s.Path()) {
+					t.Fatalf("Load: %v, want an error naming %s", err, s.Path())
+				}
+				if !bytes.Equal(m.ram, make([]byte, len(m.ram))) {
+					t.Error("rejected save was partly loaded")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !bytes.Equal(m.ram, ram) {
+				t.Fatal("loaded RAM does not match the .sav")
+			}
+			// Reloaded contents count as saved, flushing writes nothing new
+			os.Remove(s.Path())
+			if err := s.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(s.Path()); err == nil {
+				t.Error("flush right after a load rewrote the save")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cartridge
+
+import "fmt"
+
+// Type is the cartridge type byte at 0x0147
+type Type uint8
+
+// Mapper identifies the memory bank controller family of a cartridge
+type Mapper int
+
+const (
+	MapperNone Mapper = iota
+	MapperMBC1
+	MapperMBC2
+	MapperMBC3
+	MapperMBC5
+	MapperMBC6
+	MapperMBC7
+	MapperMMM01
+	MapperPocketCamera
+	MapperTAMA5
+	MapperHuC1
+	MapperHuC3
+)
+
+type typeInfo struct {
+	name    string
+	mapper  Mapper
+	ram     bool
+	battery bool
+	timer   bool
+	rumble  bool
+}
+
+var types = map[Type]typeInfo{
+	0x00: {name: "ROM ONLY", mapper: MapperNone},
+	0x01: {name: "MBC1", mapper: MapperMBC1},
+	0x02: {name: "MBC1+RAM", mapper: MapperMBC1, ram: true},
+	0x03: {name: "MBC1+RAM+BATTERY", mapper: MapperMBC1, ram: true, battery: true},
+	0x05: {name: "MBC2", mapper: MapperMBC2},
+	0x06: {name: "MBC2+BATTERY", mapper: MapperMBC2, battery: true},
+	0x08: {name: "ROM+RAM", mapper: MapperNone, ram: true},
+	0x09: {name: "ROM+RAM+BATTERY", mapper: MapperNone, ram: true, battery: true},
+	0x0B: {name: "MMM01", mapper: MapperMMM01},
+	0x0C: {name: "MMM01+RAM", mapper: MapperMMM01, ram: true},
+	0x0D: {name: "MMM01+RAM+BATTERY", mapper: MapperMMM01, ram: true, battery: true},
+	0x0F: {name: "MBC3+TIMER+BATTERY", mapper: MapperMBC3, battery: true, timer: true},
+	0x10: {name: "MBC3+TIMER+RAM+BATTERY", mapper: MapperMBC3, ram: true, battery: true, timer: true},
+	0x11: {name: "MBC3", mapper: MapperMBC3},
+	0x12: {name: "MBC3+RAM", mapper: MapperMBC3, ram: true},
+	0x13: {name: "MBC3+RAM+BATTERY", mapper: MapperMBC3, ram: true, battery: true},
+	0x19: {name: "MBC5", mapper: MapperMBC5},
+	0x1A: {name: "MBC5+RAM", mapper: MapperMBC5, ram: true},
+	0x1B: {name: "MBC5+RAM+BATTERY", mapper: MapperMBC5, ram: true, battery: true},
+	0x1C: {name: "MBC5+RUMBLE", mapper: MapperMBC5, rumble: true},
+	0x1D: {name: "MBC5+RUMBLE+RAM", mapper: MapperMBC5, ram: true, rumble: true},
+	0x1E: {name: "MBC5+RUMBLE+RAM+BATTERY", mapper: MapperMBC5, ram: true, battery: true, rumble: true},
+	0x20: {name: "MBC6", mapper: MapperMBC6, ram: true, battery: true},
+	0x22: {name: "MBC7+SENSOR+RUMBLE+RAM+BATTERY", mapper: MapperMBC7, ram: true, battery: true, rumble: true},
+	0xFC: {name: "POCKET CAMERA", mapper: MapperPocketCamera, ram: true, battery: true},
+	0xFD: {name: "BANDAI TAMA5", mapper: MapperTAMA5},
+	0xFE: {name: "HuC3", mapper: MapperHuC3, ram: true, battery: true, timer: true},
+	0xFF: {name: "HuC1+RAM+BATTERY", mapper: MapperHuC1, ram: true, battery: true},
+}
+
+// Known reports whether the type byte is a documented cartridge type
+func (t Type) Known() bool {
+	_, ok := types[t]
+	return ok
+}
+
+// Mapper returns the memory bank controller family of the cartridge
+func (t Type) Mapper() Mapper {
+	return types[t].mapper
+}
+
+// HasRAM reports whether the cartridge carries external RAM
+func (t Type) HasRAM() bool {
+	return types[t].ram
+}
+
+// HasBattery reports whether external RAM (and RTC) is battery backed
+func (t Type) HasBattery() bool {
+	return types[t].battery
+}
+
+// HasTimer reports whether the cartridge has a real-time clock
+func (t Type) HasTimer() bool {
+	return types[t].timer
+}
+
+// HasRumble reports whether the cartridge has a rumble motor
+func (t Type) HasRumble() bool {
+	return types[t].rumble
+}
+
+func (t Type) String() string {
+	if info, ok := types[t]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02X)", uint8(t))
+}
@@ -0,0 +1,137 @@
+package main
+
+const (
+	speedSwitchReg = 0xFF4D // KEY1
+	vramBankReg    = 0xFF4F // VBK
+	infraredReg    = 0xFF56 // RP
+	objPriorityReg = 0xFF6C // OPRI
+	wramBankReg    = 0xFF70 // SVBK
+	pcm12Reg       = 0xFF76 // Channel 1 and 2 digital outputs
+	pcm34Reg       = 0xFF77 // Channel 3 and 4 digital outputs
+)
+
+const speedSwitchCycles = 2050 * 4 // CPU is paused while the clock switches speed
+
+// isCGBRegister reports whether addr only exists on CGB hardware; on DMG
+// these read 0xFF and ignore writes
+func isCGBRegister(addr uint16) bool {
+	switch {
+	case addr == speedSwitchReg, addr == vramBankReg, addr == wramBankReg:
+		return true
+	case addr >= 0xFF51 && addr <= infraredReg: // HDMA1–HDMA5, RP
+		return true
+	case addr >= 0xFF68 && addr <= objPriorityReg: // Palettes, OPRI
+		return true
+	case addr >= 0xFF72 && addr <= pcm34Reg:
+		return true
+	}
+	return false
+}
+
+func (mmu *MMU) readCGBRegister(addr uint16) uint8 {
+	switch {
+	case isPaletteRegister(addr):
+		return mmu.ppu.ReadPaletteRegister(addr)
+	case isHDMARegister(addr):
+		return mmu.readHDMA(addr)
+	}
+
+	switch addr {
+	case speedSwitchReg:
+		return boolToUint8(mmu.doubleSpeed)<<7 | 0x7E | boolToUint8(mmu.speedSwitchArmed)
+	case vramBankReg:
+		return 0xFE | uint8(mmu.ppu.vramBank)
+	case wramBankReg:
+		return 0xF8 | uint8(mmu.wramBank)
+	case infraredReg:
+		// No light is ever received, bit 1 stays high
+		return mmu.infrared&0xC1 | 0x3E
+	case objPriorityReg:
+		return 0xFE | mmu.ppu.opri
+	case 0xFF72, 0xFF73, 0xFF74:
+		return mmu.undocumented[addr-0xFF72]
+	case 0xFF75:
+		return mmu.undocumented[3]&0x70 | 0x8F
+	case pcm12Reg:
+		return mmu.apu.ch2.output()<<4 | mmu.apu.ch1.output()
+	case pcm34Reg:
+		return mmu.apu.ch4.output()<<4 | mmu.apu.ch3.output()
+	}
+	return 0xFF
+}
+
+func (mmu *MMU) writeCGBRegister(addr uint16, value uint8) {
+	switch {
+	case isPaletteRegister(addr):
+		mmu.ppu.WritePaletteRegister(addr, value)
+		return
+	case isHDMARegister(addr):
+		mmu.writeHDMA(addr, value)
+		return
+	}
+
+	switch addr {
+	case speedSwitchReg:
+		// Only the prepare bit is writable
+		mmu.speedSwitchArmed = value&0x01 != 0
+	case vramBankReg:
+		mmu.ppu.vramBank = int(value & 0x01)
+	case wramBankReg:
+		mmu.wramBank = int(value & 0x07)
+		if mmu.wramBank == 0 {
+			mmu.wramBank = 1
+		}
+	case infraredReg:
+		mmu.infrared = value
+	case objPriorityReg:
+		mmu.ppu.opri = value & 0x01
+	case 0xFF72, 0xFF73, 0xFF74, 0xFF75:
+		mmu.undocumented[addr-0xFF72] = value
+	}
+}
+
+// wramAddr maps 0xC000–0xFDFF to a WRAM bank and offset: 0xD000–0xDFFF is
+// switchable and 0xE000–0xFDFF echoes 0xC000–0xDDFF
+func (mmu *MMU) wramAddr(addr uint16) (int, uint16) {
+	if addr >= 0xE000 {
+		addr -= 0x2000
+	}
+	if addr < 0xD000 {
+		return 0, addr - 0xC000
+	}
+	return mmu.wramBank, addr - 0xD000
+}
+
+func isWRAMAddr(addr uint16) bool {
+	return addr >= 0xC000 && addr < 0xFE00
+}
+
+// CGB reports whether the MMU runs in CGB mode
+func (mmu *MMU) CGB() bool {
+	return mmu.cgb
+}
+
+// cgbBootRegisters are the CPU registers the CGB boot ROM leaves for a CGB
+// cartridge; A=0x11 is how games detect the hardware
+func cgbBootRegisters() *Registers {
+	return &Registers{
+		A: 0x11, F: 0x80,
+		B: 0x00, C: 0x00,
+		D: 0xFF, E: 0x56,
+		H: 0x00, L: 0x0D,
+		PC: 0x0100,
+		SP: 0xFFFE,
+	}
+}
+
+// skipBootROM unmaps the boot ROM and sets up the I/O registers the way the
+// CGB boot ROM leaves them, since only the DMG one is embedded
+func (mmu *MMU) skipBootROM() {
+	mmu.WriteByteAt(bootDisableReg, 0x01)
+	mmu.WriteByteAt(nr52Reg, 0x80)
+	mmu.WriteByteAt(nr50Reg, 0x77)
+	mmu.WriteByteAt(nr51Reg, 0xF3)
+	mmu.WriteByteAt(bgpReg, 0xFC)
+	mmu.WriteByteAt(lcdcReg, 0x91)
+	mmu.stepCycles = 0 // Not CPU accesses
+}
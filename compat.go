@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/AlessandroGrassi99/gb-emulator/cartridge"
+)
+
+// CompatAuto picks the compatibility palette from the cartridge title like the CGB boot ROM
+const CompatAuto = "auto"
+
+// compatPalettes is the CGB boot ROM table of 4-colour RGB555 palettes used to
+// colour DMG games
+var compatPalettes = [...]uint16{
+	0x7FFF, 0x32BF, 0x00D0, 0x0000, // 0
+	0x639F, 0x4279, 0x15B0, 0x04CB,
+	0x7FFF, 0x6E31, 0x454A, 0x0000,
+	0x7FFF, 0x1BEF, 0x0200, 0x0000,
+	0x7FFF, 0x421F, 0x1CF2, 0x0000,
+	0x7FFF, 0x5294, 0x294A, 0x0000, // 5
+	0x7FFF, 0x03FF, 0x012F, 0x0000,
+	0x7FFF, 0x03EF, 0x01D6, 0x0000,
+	0x7FFF, 0x42B5, 0x3DC8, 0x0000,
+	0x7E74, 0x03FF, 0x0180, 0x0000,
+	0x67FF, 0x77AC, 0x1A13, 0x2D6B, // 10
+	0x7ED6, 0x4BFF, 0x2175, 0x0000,
+	0x53FF, 0x4A5F, 0x7E52, 0x0000,
+	0x4FFF, 0x7ED2, 0x3A4C, 0x1CE0,
+	0x03ED, 0x7FFF, 0x255F, 0x0000,
+	0x036A, 0x021F, 0x03FF, 0x7FFF, // 15
+	0x7FFF, 0x01DF, 0x0112, 0x0000,
+	0x231F, 0x035F, 0x00F2, 0x0009,
+	0x7FFF, 0x03EA, 0x011F, 0x0000,
+	0x299F, 0x001A, 0x000C, 0x0000,
+	0x7FFF, 0x027F, 0x001F, 0x0000, // 20
+	0x7FFF, 0x03E0, 0x0206, 0x0120,
+	0x7FFF, 0x7EEB, 0x001F, 0x7C00,
+	0x7FFF, 0x3FFF, 0x7E00, 0x001F,
+	0x7FFF, 0x03FF, 0x001F, 0x0000,
+	0x03FF, 0x001F, 0x000C, 0x0000, // 25
+	0x7FFF, 0x033F, 0x0193, 0x0000,
+	0x0000, 0x4200, 0x037F, 0x7FFF,
+	0x7FFF, 0x7E8C, 0x7C00, 0x0000,
+	0x7FFF, 0x1BEF, 0x6180, 0x0000,
+}
+
+// compatCombination holds the offsets in compatPalettes of the four colours of
+// OBJ0, OBJ1 and the BG
+type compatCombination struct {
+	obj0, obj1, bg uint8
+}
+
+// combine picks whole palettes by index
+func combine(obj0, obj1, bg uint8) compatCombination {
+	return compatCombination{obj0 * 4, obj1 * 4, bg * 4}
+}
+
+// compatCombinations are the palette combinations the boot ROM chooses from.
+// A few start mid-palette, hence the raw colour offsets.
+var compatCombinations = [...]compatCombination{
+	combine(4, 4, 29), // 0, default
+	combine(18, 18, 18),
+	combine(20, 20, 20),
+	combine(24, 24, 24),
+	combine(9, 9, 9),
+	combine(0, 0, 0), // 5
+	combine(27, 27, 27),
+	combine(5, 5, 5),
+	combine(12, 12, 12),
+	combine(26, 26, 26),
+	combine(16, 8, 8), // 10
+	combine(4, 28, 28),
+	combine(4, 2, 2),
+	combine(3, 4, 4),
+	combine(4, 29, 29),
+	combine(28, 4, 28), // 15
+	combine(2, 17, 2),
+	combine(16, 16, 8),
+	combine(4, 4, 7),
+	combine(4, 4, 18),
+	combine(4, 4, 20), // 20
+	combine(19, 19, 9),
+	{4*4 - 1, 4*4 - 1, 11 * 4},
+	combine(17, 17, 2),
+	combine(4, 4, 2),
+	combine(4, 4, 3), // 25
+	combine(28, 28, 0),
+	combine(3, 3, 0),
+	combine(0, 0, 1),
+	combine(18, 22, 18),
+	combine(20, 22, 20), // 30
+	combine(24, 22, 24),
+	combine(16, 22, 8),
+	combine(17, 4, 13),
+	{28*4 - 1, 0 * 4, 14 * 4},
+	{28*4 - 1, 4 * 4, 15 * 4}, // 35
+	combine(19, 22, 9),
+	combine(16, 28, 10),
+	combine(4, 23, 28),
+	combine(17, 22, 2),
+	combine(4, 0, 2), // 40
+	combine(4, 28, 3),
+	combine(28, 3, 0),
+	combine(3, 28, 4),
+	combine(21, 28, 4),
+	combine(3, 28, 0), // 45
+	combine(25, 3, 28),
+	combine(0, 28, 8),
+	combine(4, 3, 28),
+	combine(28, 3, 6),
+	combine(4, 28, 29), // 50
+}
+
+// compatButtons are the combinations picked by holding buttons during the boot animation
+var compatButtons = map[string]uint8{
+	"right":   1,
+	"left":    48,
+	"up":      5,
+	"down":    8,
+	"right+a": 0,
+	"left+a":  40,
+	"up+a":    43,
+	"down+a":  3,
+	"right+b": 6,
+	"left+b":  7,
+	"up+b":    28,
+	"down+b":  49,
+}
+
+// compatTitle keys a combination on the title checksum, and on the 4th title
+// letter when several games share the checksum
+type compatTitle struct {
+	checksum    uint8
+	letter      uint8 // 0 when the checksum is unique
+	combination uint8
+}
+
+// compatTitles lists the Nintendo games the boot ROM recognises
+var compatTitles = [...]compatTitle{
+	{0x88, 0, 4},  // ALLEY WAY
+	{0x16, 0, 5},  // YAKUMAN
+	{0x36, 0, 35}, // BASEBALL, GAME&WATCH 2
+	{0xD1, 0, 34}, // TENNIS
+	{0xDB, 0, 3},  // TETRIS
+	{0xF2, 0, 31}, // QIX
+	{0x3C, 0, 15}, // DR.MARIO
+	{0x8C, 0, 10}, // RADARMISSION
+	{0x92, 0, 5},  // F1RACE
+	{0x3D, 0, 19}, // YOSSY NO TAMAGO
+	{0x5C, 0, 36},
+	{0x58, 0, 7},  // X
+	{0xC9, 0, 37}, // MARIOLAND2
+	{0x3E, 0, 30}, // YOSSY NO COOKIE
+	{0x70, 0, 44}, // ZELDA
+	{0x1D, 0, 21},
+	{0x59, 0, 32},
+	{0x69, 0, 31}, // TETRIS FLASH
+	{0x19, 0, 20}, // DONKEY KONG
+	{0x35, 0, 5},  // MARIO'S PICROSS
+	{0xA8, 0, 33},
+	{0x14, 0, 13}, // POKEMON RED, POKEMON GREEN
+	{0xAA, 0, 14}, // PICROSS 2
+	{0x75, 0, 5},  // YOSSY NO PANEPON
+	{0x95, 0, 29}, // KIRAKIRA KIDS
+	{0x99, 0, 5},  // GAMEBOY GALLERY
+	{0x34, 0, 18}, // POCKETCAMERA
+	{0x6F, 0, 9},
+	{0x15, 0, 3},  // BALLOON KID
+	{0xFF, 0, 2},  // KINGOFTHEZOO
+	{0x97, 0, 26}, // DMG FOOTBALL
+	{0x4B, 0, 25}, // WORLD CUP
+	{0x90, 0, 25}, // OTHELLO
+	{0x17, 0, 41}, // SUPER RC PRO-AM
+	{0x10, 0, 42}, // DYNABLASTER
+	{0x39, 0, 26}, // BOY AND BLOB GB2
+	{0xF7, 0, 45}, // MEGAMAN
+	{0xF6, 0, 42}, // STAR WARS-NOA
+	{0xA2, 0, 45},
+	{0x49, 0, 36}, // WAVERACE
+	{0x4E, 0, 38},
+	{0x43, 0, 26}, // LOLO2
+	{0x68, 0, 42}, // YOSHI'S COOKIE
+	{0xE0, 0, 42}, // MYSTIC QUEST
+	{0x8B, 0, 47},
+	{0xF0, 0, 0},  // TOPRANKINGTENNIS
+	{0xCE, 0, 41}, // MANSELL
+	{0x0C, 0, 42}, // MEGAMAN3
+	{0x29, 0, 18}, // SPACE INVADERS
+	{0xE8, 0, 17}, // GAME&WATCH
+	{0xB7, 0, 17}, // DONKEYKONGLAND95
+	{0x86, 0, 2},  // ASTEROIDS/MISCMD
+	{0x9A, 0, 31}, // STREET FIGHTER 2
+	{0x52, 0, 43}, // DEFENDER/JOUST
+	{0x01, 0, 17}, // KILLERINSTINCT95
+	{0x9D, 0, 3},  // TETRIS BLAST
+	{0x71, 0, 5},  // PINOCCHIO
+	{0x9C, 0, 5},
+	{0xBD, 0, 2}, // BA.TOSHINDEN
+	{0x5D, 0, 4}, // NETTOU KOF 95
+	{0x6D, 0, 0},
+	{0x67, 0, 28}, // TETRIS PLUS
+	{0x3F, 0, 23}, // DONKEYKONGLAND 3
+
+	{0xB3, 'B', 16},
+	{0x46, 'E', 32}, // SUPER MARIOLAND
+	{0x28, 'F', 44}, // GOLF
+	{0xA5, 'A', 18}, // SOLARSTRIKER
+	{0xC6, 'A', 1},  // GBWARS
+	{0xD3, 'R', 12}, // KAERUNOTAMENI
+	{0x27, 'B', 20},
+	{0x61, 'E', 11}, // POKEMON BLUE
+	{0x18, 'K', 44}, // DONKEYKONGLAND
+	{0x66, 'E', 36}, // GAMEBOY GALLERY2
+	{0x6A, 'K', 6},  // DONKEYKONGLAND 2
+	{0xBF, ' ', 39}, // KID ICARUS
+	{0x0D, 'R', 29}, // TETRIS2
+	{0xF4, '-', 0},
+	{0xB3, 'U', 45}, // MOGURANYA
+	{0x46, 'R', 21},
+	{0x28, 'A', 35}, // GALAGA&GALAXIAN
+	{0xA5, 'R', 32}, // BT2RAGNAROKWORLD
+	{0xC6, ' ', 41}, // KEN GRIFFEY JR
+	{0xD3, 'I', 20},
+	{0x27, 'N', 45}, // MAGNETIC SOCCER
+	{0x61, 'A', 30}, // VEGAS STAKES
+	{0x18, 'I', 44},
+	{0x66, 'L', 14}, // MILLI/CENTI/PEDE
+	{0x6A, 'I', 3},  // MARIO & YOSHI
+	{0xBF, 'C', 24}, // SOCCER
+	{0x0D, 'E', 5},  // POKEBOM
+	{0xF4, ' ', 36}, // G&W GALLERY
+	{0xB3, 'R', 20}, // TETRIS ATTACK
+}
+
+// compatForHeader returns the combination the boot ROM would pick, only
+// Nintendo titles are recognised and everything else gets the default
+func compatForHeader(h *cartridge.Header) uint8 {
+	if h.LicenseeCode() != "01" {
+		return 0
+	}
+	for _, t := range compatTitles {
+		if t.checksum == h.TitleChecksum && (t.letter == 0 || t.letter == h.TitleLetter) {
+			return t.combination
+		}
+	}
+	return 0
+}
+
+// CompatPaletteNames lists the accepted -compat-palette values
+func CompatPaletteNames() []string {
+	names := []string{CompatAuto}
+	for name := range compatButtons {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// compatColors resolves a palette name, CompatAuto or a button combination such
+// as "left+a", to the BG, OBJ0 and OBJ1 colours
+func compatColors(name string, h *cartridge.Header, correct bool) ([3][4]color.RGBA, error) {
+	var colors [3][4]color.RGBA
+	var index uint8
+	name = strings.ToLower(name)
+	if name == CompatAuto {
+		index = compatForHeader(h)
+	} else {
+		var ok bool
+		if index, ok = compatButtons[name]; !ok {
+			return colors, fmt.Errorf("unknown compatibility palette %q", name)
+		}
+	}
+
+	c := compatCombinations[index]
+	for i, offset := range [3]uint8{c.bg, c.obj0, c.obj1} {
+		for shade := range 4 {
+			colors[i][shade] = rgb555ToRGBA(compatPalettes[int(offset)+shade], correct)
+		}
+	}
+	return colors, nil
+}
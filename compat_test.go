@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/AlessandroGrassi99/gb-emulator/cartridge"
+)
+
+func TestCompatColorsIgnoresCase(t *testing.T) {
+	h := &cartridge.Header{}
+	for _, names := range [][2]string{
+		{"AUTO", CompatAuto},
+		{"Auto", CompatAuto},
+		{"LEFT+A", "left+a"},
+		{"Down+B", "down+b"},
+	} {
+		got, err := compatColors(names[0], h, false)
+		if err != nil {
+			t.Errorf("%q: %v", names[0], err)
+			continue
+		}
+		want, err := compatColors(names[1], h, false)
+		if err != nil {
+			t.Fatalf("%q: %v", names[1], err)
+		}
+		if got != want {
+			t.Errorf("%q and %q pick different colours", names[0], names[1])
+		}
+	}
+
+	if _, err := compatColors("left+c", h, false); err == nil {
+		t.Error("unknown palette accepted")
+	}
+}
@@ -1,15 +1,56 @@
 package main
 
+import "fmt"
+
 type CPU struct {
 	Registers *Registers
 	Mmu       *MMU
+
+	ime          bool // Interrupt Master Enable
+	imeScheduled bool // EI was executed, IME is set after the next instruction
+
+	halted  bool // HALT: idle until an interrupt is pending
+	haltBug bool // HALT with IME=0 and a pending interrupt: next fetch does not increment PC
+	stopped bool // STOP: idle until a joypad line goes low
 }
 
 func (cpu *CPU) Step() int {
+	// A CGB VRAM DMA halts the CPU while time keeps running
+	if stall := cpu.Mmu.takeStall(); stall > 0 {
+		return stall
+	}
+
+	// Low-power states keep time running in M-cycle steps until woken up
+	if cpu.stopped {
+		if !cpu.Mmu.joypadLineLow() {
+			return 4
+		}
+		cpu.stopped = false
+	}
+	if cpu.halted {
+		if cpu.Mmu.pendingInterrupts() == 0 {
+			return 4
+		}
+		cpu.halted = false
+	}
+
+	// Interrupts are only serviced at instruction boundaries
+	if cycles := cpu.serviceInterrupt(); cycles > 0 {
+		return cycles
+	}
+
+	// EI takes effect only after the instruction following it, unless cancelled by DI
+	enableIME := cpu.imeScheduled
+
 	instr := cpu.decode()
 
 	cycles := instr.Execute(cpu, &instr)
 
+	if enableIME && cpu.imeScheduled {
+		cpu.imeScheduled = false
+		cpu.ime = true
+	}
+
 	return cycles
 }
 
@@ -32,6 +73,11 @@ func (cpu *CPU) decode() Instruction {
 func (cpu *CPU) fetchByte() uint8 {
 	addr := cpu.Registers.PC
 	val := cpu.Mmu.ReadByteAt(addr)
+	if cpu.haltBug {
+		// The byte after HALT is read twice
+		cpu.haltBug = false
+		return val
+	}
 	cpu.Registers.PC++
 	return val
 }
@@ -67,3 +113,17 @@ func (c *CPU) popWord() uint16 {
 }
 
 func halfCarryAdd(a, b byte) bool { return ((a & 0xF) + (b & 0xF)) > 0xF }
+
+// evalCondition evaluates the condition operand (NZ, Z, NC, C) of a conditional
+// JR/JP/CALL/RET. It returns the condition name, whether the branch is taken,
+// and the matching entry of instr.Cycles (taken first, not taken second).
+func (cpu *CPU) evalCondition(instr *Instruction) (string, bool, int) {
+	if len(instr.Operands) < 1 || len(instr.Cycles) < 2 {
+		panic(fmt.Sprintf("evalCondition: %s is not a conditional instruction", instr.String()))
+	}
+	cond := instr.Operands[0].Name
+	if cpu.Registers.condition(cond) {
+		return cond, true, instr.Cycles[0]
+	}
+	return cond, false, instr.Cycles[1]
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"io"
+	"log"
+	"testing"
+)
+
+// newTestCPU runs from WRAM at 0xC000 with prog loaded there. Without a
+// cartridge, ROM reads as 0xFF: RST 38 at every interrupt vector.
+func newTestCPU(prog []byte) *CPU {
+	log.SetOutput(io.Discard)
+	for idx := range opcodes {
+		if opFunc, ok := opcodesFunc[idx]; ok {
+			opcodes[idx].Execute = opFunc
+		} else {
+			opcodes[idx].Execute = OpUnimplemented
+		}
+	}
+	mmu, _ := NewMMU(nil)
+	mmu.bootEnabled = false
+	copy(mmu.wram[0][:], prog)
+	return &CPU{Mmu: mmu, Registers: &Registers{PC: 0xC000, SP: 0xFFFE}}
+}
+
+// stackTop returns the word at SP
+func stackTop(cpu *CPU) uint16 {
+	sp := cpu.Registers.SP
+	return uint16(cpu.Mmu.ReadByteAt(sp)) | uint16(cpu.Mmu.ReadByteAt(sp+1))<<8
+}
+
+func TestHaltBug(t *testing.T) {
+	cpu := newTestCPU([]byte{0x76, 0x3C}) // HALT; INC A
+	cpu.Mmu.interruptEnable = VBlankInterrupt
+	cpu.Mmu.RequestInterrupt(VBlankInterrupt)
+
+	cpu.Step()
+	if cpu.halted || !cpu.haltBug {
+		t.Fatalf("HALT with IME=0 and a pending interrupt: halted=%v haltBug=%v, want the HALT bug", cpu.halted, cpu.haltBug)
+	}
+	// INC A is read twice
+	cpu.Step()
+	cpu.Step()
+	if cpu.Registers.A != 2 || cpu.Registers.PC != 0xC002 {
+		t.Errorf("after the HALT bug A=%d PC=%04X, want A=2 PC=C002", cpu.Registers.A, cpu.Registers.PC)
+	}
+	if cpu.Mmu.interruptFlag&VBlankInterrupt == 0 {
+		t.Error("interrupt serviced with IME=0")
+	}
+}
+
+func TestHaltWakesWithoutIME(t *testing.T) {
+	cpu := newTestCPU([]byte{0x76, 0x3C}) // HALT; INC A
+	cpu.Mmu.interruptEnable = TimerInterrupt
+
+	cpu.Step()
+	if !cpu.halted {
+		t.Fatal("HALT without a pending interrupt did not halt")
+	}
+	for range 3 {
+		if got := cpu.Step(); got != 4 {
+			t.Fatalf("halted step took %d cycles, want 4", got)
+		}
+	}
+	cpu.Mmu.RequestInterrupt(TimerInterrupt)
+	cpu.Step()
+	if cpu.halted || cpu.Registers.A != 1 || cpu.Registers.PC != 0xC002 {
+		t.Errorf("after waking A=%d PC=%04X halted=%v, want INC A run once", cpu.Registers.A, cpu.Registers.PC, cpu.halted)
+	}
+}
+
+func TestEIHalt(t *testing.T) {
+	prog := make([]byte, 0x20)
+	copy(prog, []byte{0xFB, 0x76}) // EI; HALT
+	prog[0x10] = 0xD9              // RETI
+	cpu := newTestCPU(prog)
+	cpu.Mmu.interruptEnable = VBlankInterrupt
+	cpu.Mmu.RequestInterrupt(VBlankInterrupt)
+
+	cpu.Step() // EI
+	cpu.Step() // HALT
+	if cpu.halted || cpu.haltBug || !cpu.ime {
+		t.Fatalf("EI; HALT: halted=%v haltBug=%v ime=%v, want IME set and no HALT bug", cpu.halted, cpu.haltBug, cpu.ime)
+	}
+
+	if got := cpu.Step(); got != interruptCycles {
+		t.Fatalf("dispatch took %d cycles, want %d", got, interruptCycles)
+	}
+	if cpu.Registers.PC != 0x0040 || stackTop(cpu) != 0xC001 {
+		t.Fatalf("dispatch PC=%04X pushed %04X, want PC=0040 pushed the HALT address C001", cpu.Registers.PC, stackTop(cpu))
+	}
+
+	// The handler's first opcode, RST 38 on the open bus, runs once
+	cpu.Step()
+	if cpu.Registers.PC != 0x0038 || stackTop(cpu) != 0x0041 {
+		t.Fatalf("RST 38 in the handler: PC=%04X pushed %04X, want PC=0038 pushed 0041", cpu.Registers.PC, stackTop(cpu))
+	}
+
+	// Returning from the handler runs HALT again, which now halts
+	cpu.Registers.SP += 2
+	cpu.Registers.PC = 0xC010
+	cpu.Step() // RETI
+	if cpu.Registers.PC != 0xC001 {
+		t.Fatalf("RETI returned to %04X, want the HALT at C001", cpu.Registers.PC)
+	}
+	cpu.Step()
+	if !cpu.halted {
+		t.Error("HALT after RETI did not halt")
+	}
+}
+
+func TestStop(t *testing.T) {
+	tests := []struct {
+		name       string
+		armed      bool
+		wantCycles int
+		wantDouble bool
+		wantStop   bool
+	}{
+		{"KEY1 armed switches speed", true, speedSwitchCycles, true, false},
+		{"without KEY1 enters stop mode", false, 4, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := newTestCPU([]byte{0x10, 0x00, 0x3C}) // STOP; INC A
+			cpu.Mmu.cgb = true
+			cpu.Mmu.timer.counter = 0x1234
+			if tt.armed {
+				cpu.Mmu.WriteByteAt(speedSwitchReg, 0x01)
+			}
+
+			if got := cpu.Step(); got != tt.wantCycles {
+				t.Errorf("STOP took %d cycles, want %d", got, tt.wantCycles)
+			}
+			if cpu.Mmu.doubleSpeed != tt.wantDouble || cpu.stopped != tt.wantStop {
+				t.Errorf("double speed=%v stopped=%v, want %v and %v", cpu.Mmu.doubleSpeed, cpu.stopped, tt.wantDouble, tt.wantStop)
+			}
+			if got := cpu.Mmu.ReadByteAt(speedSwitchReg); got != 0x7E|boolToUint8(tt.wantDouble)<<7 {
+				t.Errorf("KEY1 = %02X after STOP", got)
+			}
+			if got := cpu.Mmu.ReadByteAt(dividerReg); got != 0 {
+				t.Errorf("DIV = %02X after STOP, want it reset", got)
+			}
+			if cpu.Registers.PC != 0xC002 {
+				t.Errorf("PC = %04X, want the byte after STOP skipped", cpu.Registers.PC)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+const (
+	dmaReg        = 0xFF46
+	dmaLength     = 0xA0 // Bytes copied to OAM
+	dmaByteCycles = 4    // One byte per M-cycle
+	dmaStartDelay = 4    // The transfer begins one M-cycle after the write
+)
+
+// oamDMA copies 160 bytes from XX00–XX9F to OAM, one byte per M-cycle. While
+// it runs the CPU only sees the I/O registers and HRAM.
+type oamDMA struct {
+	reg    uint8 // Last value written to 0xFF46
+	active bool
+	source uint16
+	index  int // Next byte to copy
+	cycles int // T-cycles accumulated towards the next byte
+
+	pending      bool // A write is waiting for its start delay
+	pendingDelay int
+}
+
+// startDMA schedules a transfer; a write during a transfer restarts it, the
+// old one keeps running until the new one begins
+func (mmu *MMU) startDMA(value uint8) {
+	mmu.dma.reg = value
+	mmu.dma.pending = true
+	mmu.dma.pendingDelay = dmaStartDelay
+}
+
+// dmaBlocksBus reports whether a CPU access to addr is cut off by a transfer
+func (mmu *MMU) dmaBlocksBus(addr uint16) bool {
+	return mmu.dma.active && addr < 0xFF00
+}
+
+func (mmu *MMU) tickDMA(cycles int) {
+	d := &mmu.dma
+	for range cycles {
+		if d.pending {
+			d.pendingDelay--
+			if d.pendingDelay == 0 {
+				d.pending = false
+				d.active = true
+				d.source = uint16(d.reg) << 8
+				d.index = 0
+				d.cycles = 0
+			}
+		}
+		if !d.active {
+			continue
+		}
+
+		d.cycles++
+		if d.cycles < dmaByteCycles {
+			continue
+		}
+		d.cycles = 0
+
+		src := d.source + uint16(d.index)
+		if src >= 0xE000 {
+			// Sources above WRAM read its echo
+			src -= 0x2000
+		}
+		mmu.ppu.oam[d.index] = mmu.readDMASource(src)
+		d.index++
+		if d.index == dmaLength {
+			d.active = false
+		}
+	}
+}
+
+// readDMASource reads a byte for the transfer. The DMA reaches VRAM on its own
+// bus, so the PPU blocking the CPU in mode 3 does not apply.
+func (mmu *MMU) readDMASource(addr uint16) uint8 {
+	if isVRAMAddr(addr) {
+		return mmu.ppu.vram[mmu.ppu.vramBank][addr-0x8000]
+	}
+	return mmu.read(addr)
+}
@@ -0,0 +1,118 @@
+package main
+
+import "testing"
+
+// newDMATestMMU fills two WRAM pages, C100 with i and C200 with 0x80+i
+func newDMATestMMU() *MMU {
+	mmu, _ := NewMMU(nil)
+	mmu.bootEnabled = false
+	for i := range 0x100 {
+		mmu.wram[0][0x100+i] = uint8(i)
+		mmu.wram[0][0x200+i] = uint8(0x80 + i)
+	}
+	return mmu
+}
+
+func TestOAMDMACopy(t *testing.T) {
+	mmu := newDMATestMMU()
+	mmu.WriteByteAt(dmaReg, 0xC1)
+	if got := mmu.ReadByteAt(dmaReg); got != 0xC1 {
+		t.Errorf("DMA register reads %02X, want C1", got)
+	}
+
+	mmu.tickDMA(dmaStartDelay + 80*dmaByteCycles)
+	if mmu.ppu.oam[79] != 79 || mmu.ppu.oam[80] != 0 {
+		t.Errorf("after 80 M-cycles OAM[79]=%02X OAM[80]=%02X, want 4F and untouched", mmu.ppu.oam[79], mmu.ppu.oam[80])
+	}
+
+	mmu.tickDMA(80 * dmaByteCycles)
+	for i := range dmaLength {
+		if mmu.ppu.oam[i] != uint8(i) {
+			t.Fatalf("OAM[%d] = %02X, want %02X", i, mmu.ppu.oam[i], i)
+		}
+	}
+	if mmu.dma.active {
+		t.Error("DMA still active after 160 bytes")
+	}
+}
+
+func TestOAMDMABlocksBus(t *testing.T) {
+	mmu := newDMATestMMU()
+	mmu.WriteByteAt(0xFF80, 0x12)
+	mmu.WriteByteAt(dmaReg, 0xC1)
+
+	// The bus is free until the transfer starts
+	if got := mmu.ReadByteAt(0xC105); got != 0x05 {
+		t.Errorf("WRAM during the start delay reads %02X, want 05", got)
+	}
+	mmu.tickDMA(dmaStartDelay)
+
+	if got := mmu.ReadByteAt(0xC105); got != 0xFF {
+		t.Errorf("WRAM during the transfer reads %02X, want FF", got)
+	}
+	mmu.WriteByteAt(0xC105, 0x99)
+	if got := mmu.ReadByteAt(0xFF80); got != 0x12 {
+		t.Errorf("HRAM during the transfer reads %02X, want 12", got)
+	}
+	mmu.WriteByteAt(0xFF81, 0x34)
+	if got := mmu.ReadByteAt(0xFF81); got != 0x34 {
+		t.Errorf("HRAM write during the transfer reads back %02X, want 34", got)
+	}
+
+	mmu.tickDMA(dmaLength * dmaByteCycles)
+	if got := mmu.ReadByteAt(0xC105); got != 0x05 {
+		t.Errorf("WRAM after the transfer reads %02X, want the blocked write dropped", got)
+	}
+}
+
+func TestOAMDMARestart(t *testing.T) {
+	mmu := newDMATestMMU()
+	mmu.WriteByteAt(dmaReg, 0xC1)
+	mmu.tickDMA(dmaStartDelay + 40*dmaByteCycles + 2)
+
+	// The old transfer keeps running, and blocking, through the new one's start delay
+	mmu.WriteByteAt(dmaReg, 0xC2)
+	mmu.tickDMA(dmaStartDelay - 1)
+	if !mmu.dma.active || mmu.ReadByteAt(0xC000) != 0xFF {
+		t.Error("bus released before the restarted transfer began")
+	}
+	if mmu.ppu.oam[40] != 40 {
+		t.Errorf("OAM[40] = %02X, want the first transfer's 28", mmu.ppu.oam[40])
+	}
+
+	mmu.tickDMA(1 + dmaLength*dmaByteCycles)
+	for i := range dmaLength {
+		if mmu.ppu.oam[i] != uint8(0x80+i) {
+			t.Fatalf("OAM[%d] = %02X, want the restarted transfer's %02X", i, mmu.ppu.oam[i], 0x80+i)
+		}
+	}
+}
+
+func TestOAMDMAFromVRAMInMode3(t *testing.T) {
+	mmu := newDMATestMMU()
+	for i := range dmaLength {
+		mmu.ppu.vram[0][0x1000+i] = uint8(0x40 + i)
+	}
+	mmu.ppu.lcdc |= lcdcEnable
+	mmu.ppu.mode = modeDrawing
+	if got := mmu.ReadByteAt(0x9000); got != 0xFF {
+		t.Fatalf("CPU reads %02X from VRAM in mode 3, want FF", got)
+	}
+
+	mmu.WriteByteAt(dmaReg, 0x90)
+	mmu.tickDMA(dmaStartDelay + dmaLength*dmaByteCycles)
+	for i := range dmaLength {
+		if mmu.ppu.oam[i] != uint8(0x40+i) {
+			t.Fatalf("OAM[%d] = %02X, want VRAM's %02X", i, mmu.ppu.oam[i], 0x40+i)
+		}
+	}
+}
+
+func TestOAMDMAEchoSource(t *testing.T) {
+	mmu := newDMATestMMU()
+	mmu.WriteByteAt(dmaReg, 0xE1) // E100 echoes C100
+	mmu.tickDMA(dmaStartDelay + dmaLength*dmaByteCycles)
+	if mmu.ppu.oam[0x10] != 0x10 {
+		t.Errorf("OAM[10] = %02X, want WRAM's echoed 10", mmu.ppu.oam[0x10])
+	}
+}
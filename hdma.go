@@ -0,0 +1,111 @@
+package main
+
+const (
+	hdma1Reg = 0xFF51 // Source high
+	hdma2Reg = 0xFF52 // Source low
+	hdma3Reg = 0xFF53 // Destination high
+	hdma4Reg = 0xFF54 // Destination low
+	hdma5Reg = 0xFF55 // Length, mode and start
+)
+
+const (
+	hdmaBlockSize   = 0x10
+	hdmaBlockCycles = 32 // Normal-speed T-cycles the CPU is halted per block
+	hdmaHBlankMode  = 1 << 7
+)
+
+// hdma is the CGB VRAM DMA: a general-purpose transfer copies everything at
+// once, an HBlank transfer copies one 16-byte block at the start of each HBlank.
+// The CPU is halted while blocks are copied.
+type hdma struct {
+	source    uint16
+	dest      uint16 // Offset in VRAM
+	remaining int    // Blocks left
+	active    bool   // HBlank transfer in progress
+}
+
+func (mmu *MMU) readHDMA(addr uint16) uint8 {
+	if addr != hdma5Reg {
+		// HDMA1–HDMA4 are write-only
+		return 0xFF
+	}
+	// Bit 7 is clear while an HBlank transfer runs; 0xFF once complete
+	length := uint8(mmu.hdma.remaining-1) & 0x7F
+	if mmu.hdma.active {
+		return length
+	}
+	return hdmaHBlankMode | length
+}
+
+func (mmu *MMU) writeHDMA(addr uint16, value uint8) {
+	h := &mmu.hdma
+	switch addr {
+	case hdma1Reg:
+		h.source = h.source&0x00FF | uint16(value)<<8
+	case hdma2Reg:
+		h.source = h.source&0xFF00 | uint16(value&0xF0)
+	case hdma3Reg:
+		h.dest = h.dest&0x00F0 | uint16(value&0x1F)<<8
+	case hdma4Reg:
+		h.dest = h.dest&0x1F00 | uint16(value&0xF0)
+	case hdma5Reg:
+		if h.active && value&hdmaHBlankMode == 0 {
+			// Cancels the HBlank transfer, the remaining length stays readable
+			h.active = false
+			return
+		}
+		h.remaining = int(value&0x7F) + 1
+		if value&hdmaHBlankMode == 0 {
+			mmu.copyHDMABlocks(h.remaining)
+			return
+		}
+		h.active = true
+		if mmu.ppu.lcdc&lcdcEnable == 0 || mmu.ppu.mode == modeHBlank {
+			// No HBlank will start soon enough, the first block goes right away
+			mmu.hblankDMA()
+		}
+	}
+}
+
+// hblankDMA copies the next block of an HBlank transfer, called at the start of HBlank
+func (mmu *MMU) hblankDMA() {
+	if !mmu.hdma.active {
+		return
+	}
+	mmu.copyHDMABlocks(1)
+	if mmu.hdma.remaining == 0 {
+		mmu.hdma.active = false
+	}
+}
+
+// copyHDMABlocks copies blocks to the current VRAM bank and halts the CPU for
+// the time it takes, which doubles in CPU cycles at double speed
+func (mmu *MMU) copyHDMABlocks(blocks int) {
+	h := &mmu.hdma
+	vram := &mmu.ppu.vram[mmu.ppu.vramBank]
+	for range blocks {
+		for range hdmaBlockSize {
+			vram[h.dest&0x1FFF] = mmu.read(h.source)
+			h.source++
+			h.dest++
+		}
+		h.remaining--
+	}
+
+	stall := blocks * hdmaBlockCycles
+	if mmu.doubleSpeed {
+		stall *= 2
+	}
+	mmu.stallCycles += stall
+}
+
+// takeStall returns and clears the cycles the CPU must stay halted for
+func (mmu *MMU) takeStall() int {
+	stall := mmu.stallCycles
+	mmu.stallCycles = 0
+	return stall
+}
+
+func isHDMARegister(addr uint16) bool {
+	return addr >= hdma1Reg && addr <= hdma5Reg
+}
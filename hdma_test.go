@@ -0,0 +1,184 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/AlessandroGrassi99/gb-emulator/cartridge"
+)
+
+// newCGBTestMMU returns a CGB MMU with WRAM C000–C0FF filled with i
+func newCGBTestMMU(t *testing.T) *MMU {
+	t.Helper()
+	cart := &cartridge.Cartridge{
+		Header: cartridge.Header{CGBFlag: 0x80},
+		ROM:    make([]byte, 0x8000),
+	}
+	mmu, err := NewMMU(cart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mmu.bootEnabled = false
+	for i := range 0x100 {
+		mmu.wram[0][i] = uint8(i)
+	}
+	return mmu
+}
+
+// setupHDMA programs the source, destination and then HDMA5
+func setupHDMA(mmu *MMU, source, dest uint16, hdma5 uint8) {
+	mmu.WriteByteAt(hdma1Reg, uint8(source>>8))
+	mmu.WriteByteAt(hdma2Reg, uint8(source))
+	mmu.WriteByteAt(hdma3Reg, uint8(dest>>8))
+	mmu.WriteByteAt(hdma4Reg, uint8(dest))
+	mmu.WriteByteAt(hdma5Reg, hdma5)
+}
+
+// copiedBlocks counts the 16-byte blocks of C000 found at VRAM bank 0 offset 0x0100
+func copiedBlocks(mmu *MMU) int {
+	blocks := 0
+	for ; blocks < 8; blocks++ {
+		if mmu.ppu.vram[0][0x100+blocks*hdmaBlockSize+1] != uint8(blocks*hdmaBlockSize+1) {
+			break
+		}
+	}
+	return blocks
+}
+
+func TestGeneralDMA(t *testing.T) {
+	tests := []struct {
+		name      string
+		double    bool
+		hdma5     uint8
+		wantStall int
+	}{
+		{"one block", false, 0x00, 32},
+		{"four blocks", false, 0x03, 128},
+		{"four blocks at double speed", true, 0x03, 256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mmu := newCGBTestMMU(t)
+			mmu.doubleSpeed = tt.double
+			setupHDMA(mmu, 0xC000, 0x8100, tt.hdma5)
+
+			if got, want := copiedBlocks(mmu), int(tt.hdma5)+1; got != want {
+				t.Errorf("copied %d blocks, want %d", got, want)
+			}
+			if got := mmu.ReadByteAt(hdma5Reg); got != 0xFF {
+				t.Errorf("HDMA5 after the transfer reads %02X, want FF", got)
+			}
+
+			// The CPU is halted for the copy, time keeps running
+			cpu := &CPU{Mmu: mmu, Registers: &Registers{PC: 0xC000}}
+			if got := cpu.Step(); got != tt.wantStall {
+				t.Errorf("CPU stalled for %d cycles, want %d", got, tt.wantStall)
+			}
+			if cpu.Registers.PC != 0xC000 {
+				t.Error("CPU ran an instruction during the stall")
+			}
+		})
+	}
+}
+
+func TestHDMAAddressMasks(t *testing.T) {
+	mmu := newCGBTestMMU(t)
+	mmu.WriteByteAt(vramBankReg, 1)
+	// The source low nibble is ignored, the destination keeps only 0x1FF0
+	setupHDMA(mmu, 0xC00F, 0xE10F, 0x00)
+	if got := mmu.ppu.vram[1][0x0100]; got != 0x00 {
+		t.Errorf("VRAM bank 1 0x8100 = %02X, want C000's 00", got)
+	}
+	if got := mmu.ppu.vram[1][0x010F]; got != 0x0F {
+		t.Errorf("VRAM bank 1 0x810F = %02X, want C00F's 0F", got)
+	}
+	if copiedBlocks(mmu) != 0 {
+		t.Error("transfer went to VRAM bank 0 with bank 1 selected")
+	}
+	for _, reg := range []uint16{hdma1Reg, hdma2Reg, hdma3Reg, hdma4Reg} {
+		if got := mmu.ReadByteAt(reg); got != 0xFF {
+			t.Errorf("write-only %04X reads %02X", reg, got)
+		}
+	}
+}
+
+func TestHBlankDMA(t *testing.T) {
+	mmu := newCGBTestMMU(t)
+	mmu.ppu.lcdc |= lcdcEnable
+	mmu.ppu.mode = modeDrawing
+	setupHDMA(mmu, 0xC000, 0x8100, hdmaHBlankMode|0x02) // 3 blocks
+
+	if copiedBlocks(mmu) != 0 || mmu.takeStall() != 0 {
+		t.Fatal("HBlank transfer copied before HBlank")
+	}
+	for want := range 3 {
+		if got, reg := copiedBlocks(mmu), mmu.ReadByteAt(hdma5Reg); got != want || reg != uint8(2-want) {
+			t.Fatalf("before HBlank %d: %d blocks, HDMA5=%02X, want %d and %02X", want, got, reg, want, 2-want)
+		}
+		mmu.hblankDMA()
+		if got := mmu.takeStall(); got != hdmaBlockCycles {
+			t.Errorf("HBlank block stalled the CPU %d cycles, want %d", got, hdmaBlockCycles)
+		}
+	}
+	if got := mmu.ReadByteAt(hdma5Reg); got != 0xFF || mmu.hdma.active {
+		t.Errorf("HDMA5 after the last block reads %02X active=%v, want FF and done", got, mmu.hdma.active)
+	}
+	mmu.hblankDMA()
+	if copiedBlocks(mmu) != 3 || mmu.takeStall() != 0 {
+		t.Error("HBlank after the end copied again")
+	}
+}
+
+func TestHBlankDMAStartsInHBlank(t *testing.T) {
+	for _, lcdOn := range []bool{false, true} {
+		mmu := newCGBTestMMU(t)
+		if lcdOn {
+			mmu.ppu.lcdc |= lcdcEnable
+			mmu.ppu.mode = modeHBlank
+		}
+		setupHDMA(mmu, 0xC000, 0x8100, hdmaHBlankMode|0x01)
+		if got := copiedBlocks(mmu); got != 1 {
+			t.Errorf("LCD on=%v: started in HBlank copied %d blocks, want the first right away", lcdOn, got)
+		}
+		if got := mmu.ReadByteAt(hdma5Reg); got != 0x00 {
+			t.Errorf("LCD on=%v: HDMA5 reads %02X, want 00", lcdOn, got)
+		}
+	}
+}
+
+func TestHBlankDMACancel(t *testing.T) {
+	mmu := newCGBTestMMU(t)
+	mmu.ppu.lcdc |= lcdcEnable
+	mmu.ppu.mode = modeDrawing
+	setupHDMA(mmu, 0xC000, 0x8100, hdmaHBlankMode|0x04) // 5 blocks
+	mmu.hblankDMA()
+
+	mmu.WriteByteAt(hdma5Reg, 0x00)
+	if mmu.hdma.active {
+		t.Fatal("writing bit 7 clear did not cancel the transfer")
+	}
+	// Bit 7 set: not running, the low bits keep the remaining length
+	if got := mmu.ReadByteAt(hdma5Reg); got != 0x83 {
+		t.Errorf("HDMA5 after cancel reads %02X, want 83", got)
+	}
+	mmu.hblankDMA()
+	if got := copiedBlocks(mmu); got != 1 {
+		t.Errorf("%d blocks copied, want 1 before the cancel", got)
+	}
+}
+
+func TestHBlankDMAOneBlockPerLine(t *testing.T) {
+	mmu := newCGBTestMMU(t)
+	mmu.WriteByteAt(lcdcReg, lcdcEnable)
+	setupHDMA(mmu, 0xC000, 0x8100, hdmaHBlankMode|0x07) // 8 blocks
+	for line := 1; line <= 8; line++ {
+		for range dotsPerLine / 4 {
+			mmu.Tick(4)
+		}
+		if got := copiedBlocks(mmu); got != line {
+			t.Fatalf("after %d lines %d blocks copied, want %d", line, got, line)
+		}
+	}
+	if mmu.ReadByteAt(hdma5Reg) != 0xFF {
+		t.Error("transfer not complete after 8 lines")
+	}
+}
@@ -263,24 +263,29 @@ func OpCbBitBR8(cpu *CPU, instr *Instruction) int {
 // OpJrCondImm8 Handles JR cond, e8
 func OpJrCondImm8(cpu *CPU, instr *Instruction) int {
 	offset := cpu.fetchByte()
-	if !cpu.Registers.getFlag(ZeroFlag) {
+	from := cpu.opcodeAddr(instr)
+
+	cond, taken, cycles := cpu.evalCondition(instr)
+	if taken {
 		cpu.Registers.addPC(int8(offset))
 		log.Printf(
-			"0x%04X:\t%-12s ; offset=0x%02X → PC=0x%04X (jump taken)",
-			cpu.opcodeAddr(instr),
+			"0x%04X:\t%-12s ; %s, offset=0x%02X → PC=0x%04X (jump taken)",
+			from,
 			instr.String(),
+			cond,
 			offset,
 			cpu.Registers.PC,
 		)
-		return instr.Cycles[0]
+		return cycles
 	}
 	log.Printf(
-		"0x%04X:\t%-12s ; zero flag set → no jump",
-		cpu.opcodeAddr(instr),
+		"0x%04X:\t%-12s ; not %s → no jump",
+		from,
 		instr.String(),
+		cond,
 	)
 
-	return instr.Cycles[1]
+	return cycles
 }
 
 // OpLdR8N8 Handles LD [A, B, C, D, E, H, L], n8
@@ -583,3 +588,1215 @@ func OpPopR16(cpu *CPU, instr *Instruction) int {
 	)
 	return instr.Cycles[0]
 }
+
+func OpLdMemDEA(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getDE()
+	value := cpu.Registers.getA()
+	cpu.Mmu.WriteByteAt(addr, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; DE=0x%04X → [0x%04X]=0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		addr,
+		value,
+	)
+
+	return instr.Cycles[0]
+}
+
+func OpLdAMemBC(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getBC()
+	value := cpu.Mmu.ReadByteAt(addr)
+	cpu.Registers.setA(value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [0x%04X]=0x%02X → A=0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		value,
+		cpu.Registers.getA(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdR8MemHL Handles LD [A, B, C, D, E, H, L], (HL) with optional increment/decrement
+func OpLdR8MemHL(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 2 {
+		panic(fmt.Sprintf("OpLdR8MemHL expects 2 operands, got %d for %s", len(instr.Operands), instr.String()))
+	}
+
+	isIncrement := instr.Operands[1].Increment
+	isDecrement := instr.Operands[1].Decrement
+	targetReg := instr.Operands[0].Name
+	setter := cpu.Registers.getRegisterSetter8(targetReg)
+	if setter == nil {
+		panic(fmt.Sprintf("OpLdR8MemHL: Unexpected target register %q", targetReg))
+	}
+
+	addr := cpu.Registers.getHL()
+	value := cpu.Mmu.ReadByteAt(addr)
+	setter(value)
+
+	// Handle increment/decrement after memory operation
+	if isIncrement {
+		cpu.Registers.setHL(addr + 1)
+	} else if isDecrement {
+		cpu.Registers.setHL(addr - 1)
+	}
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → %s ; HL=0x%04X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		value,
+		targetReg,
+		cpu.Registers.getHL(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdMemHLN8 Handles LD (HL), n8
+func OpLdMemHLN8(cpu *CPU, instr *Instruction) int {
+	value := cpu.fetchByte()
+	addr := cpu.Registers.getHL()
+	cpu.Mmu.WriteByteAt(addr, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; n=0x%02X → [HL=0x%04X]",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		value,
+		addr,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdMemImm16A Handles LD (a16), A
+func OpLdMemImm16A(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	value := cpu.Registers.getA()
+	cpu.Mmu.WriteByteAt(addr, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → [0x%04X]",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		value,
+		addr,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdAMemImm16 Handles LD A, (a16)
+func OpLdAMemImm16(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	value := cpu.Mmu.ReadByteAt(addr)
+	cpu.Registers.setA(value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [0x%04X]=0x%02X → A",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		value,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdMemImm16SP Handles LD (a16), SP
+func OpLdMemImm16SP(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	sp := cpu.Registers.getSP()
+	cpu.Mmu.WriteByteAt(addr, uint8(sp))
+	cpu.Mmu.WriteByteAt(addr+1, uint8(sp>>8))
+
+	log.Printf(
+		"0x%04X:\t%-12s ; SP=0x%04X → [0x%04X]",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		sp,
+		addr,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdSPHL Handles LD SP, HL
+func OpLdSPHL(cpu *CPU, instr *Instruction) int {
+	value := cpu.Registers.getHL()
+	cpu.Registers.setSP(value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; SP = HL = 0x%04X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		value,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdHLSPImm8 Handles LD HL, SP+e8
+func OpLdHLSPImm8(cpu *CPU, instr *Instruction) int {
+	offset := cpu.fetchByte()
+	res := cpu.addSPE8(offset)
+	cpu.Registers.setHL(res)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; e8=%d → HL=0x%04X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		int8(offset),
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdhAMemImm8 Handles LDH A, (a8)
+func OpLdhAMemImm8(cpu *CPU, instr *Instruction) int {
+	offset := cpu.fetchByte()
+	addr := uint16(0xFF00) | uint16(offset)
+	value := cpu.Mmu.ReadByteAt(addr)
+	cpu.Registers.setA(value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; a8=0x%02X → A=[0x%04X]=0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		offset,
+		addr,
+		value,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpLdhAMemC Handles LDH A, (C)
+func OpLdhAMemC(cpu *CPU, instr *Instruction) int {
+	addr := uint16(0xFF00) | uint16(cpu.Registers.getC())
+	value := cpu.Mmu.ReadByteAt(addr)
+	cpu.Registers.setA(value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; C=0x%02X → A=[0x%04X]=0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		cpu.Registers.getC(),
+		addr,
+		value,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpIncR16 Handles INC rr (BC, DE, HL, SP)
+func OpIncR16(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 1 {
+		panic(fmt.Sprintf("OpIncR16 expects 1 operand, got 0 for %s", instr.String()))
+	}
+	regName := instr.Operands[0].Name
+	getter := cpu.Registers.getRegisterGetter16(regName)
+	setter := cpu.Registers.getRegisterSetter16(regName)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("OpIncR16: Unexpected register %q", regName))
+	}
+
+	orig := getter()
+	res := orig + 1
+	setter(res)
+	// Flags are not affected
+
+	log.Printf("0x%04X:\t%-12s ; %s=0x%04X → 0x%04X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		regName, orig, res,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpDecR16 Handles DEC rr (BC, DE, HL, SP)
+func OpDecR16(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 1 {
+		panic(fmt.Sprintf("OpDecR16 expects 1 operand, got 0 for %s", instr.String()))
+	}
+	regName := instr.Operands[0].Name
+	getter := cpu.Registers.getRegisterGetter16(regName)
+	setter := cpu.Registers.getRegisterSetter16(regName)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("OpDecR16: Unexpected register %q", regName))
+	}
+
+	orig := getter()
+	res := orig - 1
+	setter(res)
+	// Flags are not affected
+
+	log.Printf("0x%04X:\t%-12s ; %s=0x%04X → 0x%04X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		regName, orig, res,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpAddHLR16 Handles ADD HL, rr (BC, DE, HL, SP)
+func OpAddHLR16(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 2 {
+		panic(fmt.Sprintf("OpAddHLR16 expects 2 operands, got %d for %s", len(instr.Operands), instr.String()))
+	}
+	sourceReg := instr.Operands[1].Name
+	getter := cpu.Registers.getRegisterGetter16(sourceReg)
+	if getter == nil {
+		panic(fmt.Sprintf("OpAddHLR16: Unexpected source register %q", sourceReg))
+	}
+
+	hl := cpu.Registers.getHL()
+	value := getter()
+	sum := uint32(hl) + uint32(value)
+	res := uint16(sum)
+	cpu.Registers.setHL(res)
+
+	// Z is not affected, H is the carry from bit 11, C the carry from bit 15
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, (hl&0x0FFF)+(value&0x0FFF) > 0x0FFF)
+	cpu.Registers.setFlag(CarryFlag, sum > 0xFFFF)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; HL=0x%04X + %s=0x%04X → HL=0x%04X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		hl,
+		sourceReg,
+		value,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpAddSPE8 Handles ADD SP, e8
+func OpAddSPE8(cpu *CPU, instr *Instruction) int {
+	offset := cpu.fetchByte()
+	orig := cpu.Registers.getSP()
+	res := cpu.addSPE8(offset)
+	cpu.Registers.setSP(res)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; SP=0x%04X + %d → SP=0x%04X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		orig,
+		int8(offset),
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpDecR8 Handles DEC r [A, B, C, D, E, H, L]
+func OpDecR8(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 1 {
+		panic(fmt.Sprintf("OpDecR8 expects 1 operand, got 0 for %s", instr.String()))
+	}
+	regName := instr.Operands[0].Name
+	getter := cpu.Registers.getRegisterGetter8(regName)
+	setter := cpu.Registers.getRegisterSetter8(regName)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("OpDecR8: Unexpected register %q", regName))
+	}
+
+	orig := getter()
+	res := orig - 1
+	setter(res)
+	// Carry flag is not affected
+	cpu.Registers.dec8Flags(orig, res)
+
+	log.Printf("0x%04X:\t%-12s ; %s=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		regName, orig, res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpIncMemHL Handles INC (HL)
+func OpIncMemHL(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getHL()
+	orig := cpu.Mmu.ReadByteAt(addr)
+	res := orig + 1
+	cpu.Mmu.WriteByteAt(addr, res)
+	// Carry flag is not affected
+	cpu.Registers.inc8Flags(orig, res)
+
+	log.Printf("0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr, orig, res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpDecMemHL Handles DEC (HL)
+func OpDecMemHL(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getHL()
+	orig := cpu.Mmu.ReadByteAt(addr)
+	res := orig - 1
+	cpu.Mmu.WriteByteAt(addr, res)
+	// Carry flag is not affected
+	cpu.Registers.dec8Flags(orig, res)
+
+	log.Printf("0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr, orig, res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// opAluAR8 Handles the ALU A, r forms, the operation is taken from the mnemonic
+func opAluAR8(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 2 {
+		panic(fmt.Sprintf("%s A, r8 expects 2 operands, got %d for %s", instr.Mnemonic, len(instr.Operands), instr.String()))
+	}
+	sourceReg := instr.Operands[1].Name
+	getter := cpu.Registers.getRegisterGetter8(sourceReg)
+	if getter == nil {
+		panic(fmt.Sprintf("%s A, r8: Unexpected source register %q", instr.Mnemonic, sourceReg))
+	}
+
+	orig := cpu.Registers.getA()
+	value := getter()
+	res := cpu.alu8(instr.Mnemonic, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X, %s=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		orig,
+		sourceReg,
+		value,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// opAluAN8 Handles the ALU A, n8 forms, the operation is taken from the mnemonic
+func opAluAN8(cpu *CPU, instr *Instruction) int {
+	orig := cpu.Registers.getA()
+	value := cpu.fetchByte()
+	res := cpu.alu8(instr.Mnemonic, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X, n=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		orig,
+		value,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// opAluAMemHL Handles the ALU A, (HL) forms, the operation is taken from the mnemonic
+func opAluAMemHL(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getHL()
+	orig := cpu.Registers.getA()
+	value := cpu.Mmu.ReadByteAt(addr)
+	res := cpu.alu8(instr.Mnemonic, value)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X, [HL=0x%04X]=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		orig,
+		addr,
+		value,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpAddAR8 Handles ADD A, r
+func OpAddAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpAddAN8 Handles ADD A, n8
+func OpAddAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpAddAMemHL Handles ADD A, (HL)
+func OpAddAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpAdcAR8 Handles ADC A, r
+func OpAdcAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpAdcAN8 Handles ADC A, n8
+func OpAdcAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpAdcAMemHL Handles ADC A, (HL)
+func OpAdcAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpSubAR8 Handles SUB A, r
+func OpSubAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpSubAN8 Handles SUB A, n8
+func OpSubAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpSubAMemHL Handles SUB A, (HL)
+func OpSubAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpSbcAR8 Handles SBC A, r
+func OpSbcAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpSbcAN8 Handles SBC A, n8
+func OpSbcAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpSbcAMemHL Handles SBC A, (HL)
+func OpSbcAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpAndAR8 Handles AND A, r
+func OpAndAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpAndAN8 Handles AND A, n8
+func OpAndAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpAndAMemHL Handles AND A, (HL)
+func OpAndAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpXorAN8 Handles XOR A, n8
+func OpXorAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpXorAMemHL Handles XOR A, (HL)
+func OpXorAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpOrAR8 Handles OR A, r
+func OpOrAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpOrAN8 Handles OR A, n8
+func OpOrAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpOrAMemHL Handles OR A, (HL)
+func OpOrAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+// OpCpAR8 Handles CP A, r
+func OpCpAR8(cpu *CPU, instr *Instruction) int { return opAluAR8(cpu, instr) }
+
+// OpCpAN8 Handles CP A, n8
+func OpCpAN8(cpu *CPU, instr *Instruction) int { return opAluAN8(cpu, instr) }
+
+// OpCpAMemHL Handles CP A, (HL)
+func OpCpAMemHL(cpu *CPU, instr *Instruction) int { return opAluAMemHL(cpu, instr) }
+
+func OpRlca(cpu *CPU, instr *Instruction) int {
+	a := cpu.Registers.getA()
+	newCarry := (a & 0x80) != 0
+	res := (a << 1) | (a >> 7)
+
+	cpu.Registers.setA(res)
+
+	cpu.Registers.setFlag(ZeroFlag, false)
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, newCarry)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → A=0x%02X ; Z=false N=false H=false C=%t",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		a,
+		res,
+		newCarry,
+	)
+
+	return instr.Cycles[0]
+}
+
+func OpRrca(cpu *CPU, instr *Instruction) int {
+	a := cpu.Registers.getA()
+	newCarry := (a & 0x01) != 0
+	res := (a >> 1) | (a << 7)
+
+	cpu.Registers.setA(res)
+
+	cpu.Registers.setFlag(ZeroFlag, false)
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, newCarry)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → A=0x%02X ; Z=false N=false H=false C=%t",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		a,
+		res,
+		newCarry,
+	)
+
+	return instr.Cycles[0]
+}
+
+func OpRra(cpu *CPU, instr *Instruction) int {
+	a := cpu.Registers.getA()
+	carryIn := cpu.Registers.getFlag(CarryFlag)
+	newCarry := (a & 0x01) != 0
+	res := (a >> 1) | (boolToUint8(carryIn) << 7)
+
+	cpu.Registers.setA(res)
+
+	cpu.Registers.setFlag(ZeroFlag, false)
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, newCarry)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → A=0x%02X ; Z=false N=false H=false C=%t",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		a,
+		res,
+		newCarry,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpDaa Handles DAA, adjusting A to a valid BCD result after an addition or subtraction
+func OpDaa(cpu *CPU, instr *Instruction) int {
+	a := cpu.Registers.getA()
+	res := a
+	carry := cpu.Registers.getFlag(CarryFlag)
+
+	var adjust uint8
+	if cpu.Registers.getFlag(SubtractFlag) {
+		if cpu.Registers.getFlag(HalfCarryFlag) {
+			adjust |= 0x06
+		}
+		if carry {
+			adjust |= 0x60
+		}
+		res -= adjust
+	} else {
+		if cpu.Registers.getFlag(HalfCarryFlag) || (a&0x0F) > 0x09 {
+			adjust |= 0x06
+		}
+		if carry || a > 0x99 {
+			adjust |= 0x60
+			carry = true
+		}
+		res += adjust
+	}
+	cpu.Registers.setA(res)
+
+	// N is not affected
+	cpu.Registers.setFlag(ZeroFlag, res == 0)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, carry)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → A=0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		a,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCpl Handles CPL, complementing A
+func OpCpl(cpu *CPU, instr *Instruction) int {
+	a := cpu.Registers.getA()
+	res := ^a
+	cpu.Registers.setA(res)
+
+	cpu.Registers.setFlag(SubtractFlag, true)
+	cpu.Registers.setFlag(HalfCarryFlag, true)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; A=0x%02X → A=0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		a,
+		res,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpScf Handles SCF, setting the carry flag
+func OpScf(cpu *CPU, instr *Instruction) int {
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, true)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCcf Handles CCF, complementing the carry flag
+func OpCcf(cpu *CPU, instr *Instruction) int {
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, false)
+	cpu.Registers.setFlag(CarryFlag, !cpu.Registers.getFlag(CarryFlag))
+
+	log.Printf(
+		"0x%04X:\t%-12s ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpJrImm8 Handles JR e8
+func OpJrImm8(cpu *CPU, instr *Instruction) int {
+	offset := cpu.fetchByte()
+	cpu.Registers.addPC(int8(offset))
+
+	log.Printf(
+		"0x%04X:\t%-12s ; offset=0x%02X → PC=0x%04X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		offset,
+		cpu.Registers.PC,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpJpImm16 Handles JP a16
+func OpJpImm16(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	from := cpu.opcodeAddr(instr)
+	cpu.Registers.setPC(addr)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; PC=0x%04X",
+		from,
+		instr.String(),
+		addr,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpJpHL Handles JP HL
+func OpJpHL(cpu *CPU, instr *Instruction) int {
+	from := cpu.opcodeAddr(instr)
+	addr := cpu.Registers.getHL()
+	cpu.Registers.setPC(addr)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; PC=HL=0x%04X",
+		from,
+		instr.String(),
+		addr,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpJpCondImm16 Handles JP cond, a16
+func OpJpCondImm16(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	from := cpu.opcodeAddr(instr)
+
+	cond, taken, cycles := cpu.evalCondition(instr)
+	if taken {
+		cpu.Registers.setPC(addr)
+		log.Printf(
+			"0x%04X:\t%-12s ; %s → PC=0x%04X (jump taken)",
+			from,
+			instr.String(),
+			cond,
+			addr,
+		)
+		return cycles
+	}
+	log.Printf(
+		"0x%04X:\t%-12s ; not %s → no jump",
+		from,
+		instr.String(),
+		cond,
+	)
+
+	return cycles
+}
+
+// OpCallCondImm16 Handles CALL cond, a16
+func OpCallCondImm16(cpu *CPU, instr *Instruction) int {
+	addr := cpu.fetchWord()
+	from := cpu.opcodeAddr(instr)
+
+	cond, taken, cycles := cpu.evalCondition(instr)
+	if taken {
+		retAddr := cpu.Registers.getPC()
+		cpu.pushWord(retAddr)
+		cpu.Registers.setPC(addr)
+		log.Printf(
+			"0x%04X:\t%-12s ; %s → PC=0x%04X; pushed ret=0x%04X; SP=0x%04X",
+			from,
+			instr.String(),
+			cond,
+			addr,
+			retAddr,
+			cpu.Registers.getSP(),
+		)
+		return cycles
+	}
+	log.Printf(
+		"0x%04X:\t%-12s ; not %s → no call",
+		from,
+		instr.String(),
+		cond,
+	)
+
+	return cycles
+}
+
+// OpRet Handles RET
+func OpRet(cpu *CPU, instr *Instruction) int {
+	from := cpu.opcodeAddr(instr)
+	addr := cpu.popWord()
+	cpu.Registers.setPC(addr)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; popped PC=0x%04X; SP=0x%04X",
+		from,
+		instr.String(),
+		addr,
+		cpu.Registers.getSP(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpRetCond Handles RET cond
+func OpRetCond(cpu *CPU, instr *Instruction) int {
+	from := cpu.opcodeAddr(instr)
+
+	cond, taken, cycles := cpu.evalCondition(instr)
+	if taken {
+		addr := cpu.popWord()
+		cpu.Registers.setPC(addr)
+		log.Printf(
+			"0x%04X:\t%-12s ; %s → popped PC=0x%04X; SP=0x%04X",
+			from,
+			instr.String(),
+			cond,
+			addr,
+			cpu.Registers.getSP(),
+		)
+		return cycles
+	}
+	log.Printf(
+		"0x%04X:\t%-12s ; not %s → no return",
+		from,
+		instr.String(),
+		cond,
+	)
+
+	return cycles
+}
+
+// OpRstVec Handles RST vec ($00, $08, ..., $38)
+func OpRstVec(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 1 {
+		panic(fmt.Sprintf("OpRstVec expects 1 operand, got 0 for %s", instr.String()))
+	}
+	vecStr := instr.Operands[0].Name
+	vec, err := parseHexToUint8("0x" + strings.TrimPrefix(vecStr, "$"))
+	if err != nil {
+		panic(fmt.Sprintf("OpRstVec: Invalid vector %q: %v", vecStr, err))
+	}
+
+	from := cpu.opcodeAddr(instr)
+	retAddr := cpu.Registers.getPC()
+	cpu.pushWord(retAddr)
+	cpu.Registers.setPC(uint16(vec))
+
+	log.Printf(
+		"0x%04X:\t%-12s ; PC=0x%04X; pushed ret=0x%04X; SP=0x%04X",
+		from,
+		instr.String(),
+		cpu.Registers.getPC(),
+		retAddr,
+		cpu.Registers.getSP(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// cbBitIndex extracts the bit number from the first operand of BIT/RES/SET
+func cbBitIndex(instr *Instruction) uint {
+	if len(instr.Operands) < 2 {
+		panic(fmt.Sprintf("%s expects 2 operands, got %d for %s", instr.Mnemonic, len(instr.Operands), instr.String()))
+	}
+	bitStr := instr.Operands[0].Name
+	var bitNum uint
+	_, err := fmt.Sscanf(bitStr, "%d", &bitNum)
+	if err != nil || bitNum > 7 {
+		panic(fmt.Sprintf("%s: Invalid bit number %q", instr.Mnemonic, bitStr))
+	}
+	return bitNum
+}
+
+// opCbShiftR8 Handles the CB rotate/shift r forms, the operation is taken from the mnemonic
+func opCbShiftR8(cpu *CPU, instr *Instruction) int {
+	if len(instr.Operands) < 1 {
+		panic(fmt.Sprintf("%s r8 expects 1 operand, got 0 for %s", instr.Mnemonic, instr.String()))
+	}
+	targetReg := instr.Operands[0].Name
+	getter := cpu.Registers.getRegisterGetter8(targetReg)
+	setter := cpu.Registers.getRegisterSetter8(targetReg)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("%s r8: Unexpected register %q", instr.Mnemonic, targetReg))
+	}
+
+	old := getter()
+	result := cpu.rotateShift(instr.Mnemonic, old)
+	setter(result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; %s=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		targetReg,
+		old,
+		result,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// opCbShiftMemHL Handles the CB rotate/shift (HL) forms, the operation is taken from the mnemonic
+func opCbShiftMemHL(cpu *CPU, instr *Instruction) int {
+	addr := cpu.Registers.getHL()
+	old := cpu.Mmu.ReadByteAt(addr)
+	result := cpu.rotateShift(instr.Mnemonic, old)
+	cpu.Mmu.WriteByteAt(addr, result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → 0x%02X ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		old,
+		result,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCbRlcR8 Handles RLC r
+func OpCbRlcR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbRlcMemHL Handles RLC (HL)
+func OpCbRlcMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbRrcR8 Handles RRC r
+func OpCbRrcR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbRrcMemHL Handles RRC (HL)
+func OpCbRrcMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbRlMemHL Handles RL (HL)
+func OpCbRlMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbRrR8 Handles RR r
+func OpCbRrR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbRrMemHL Handles RR (HL)
+func OpCbRrMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbSlaR8 Handles SLA r
+func OpCbSlaR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbSlaMemHL Handles SLA (HL)
+func OpCbSlaMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbSraR8 Handles SRA r
+func OpCbSraR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbSraMemHL Handles SRA (HL)
+func OpCbSraMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbSwapR8 Handles SWAP r
+func OpCbSwapR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbSwapMemHL Handles SWAP (HL)
+func OpCbSwapMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbSrlR8 Handles SRL r
+func OpCbSrlR8(cpu *CPU, instr *Instruction) int { return opCbShiftR8(cpu, instr) }
+
+// OpCbSrlMemHL Handles SRL (HL)
+func OpCbSrlMemHL(cpu *CPU, instr *Instruction) int { return opCbShiftMemHL(cpu, instr) }
+
+// OpCbBitBMemHL Handles BIT b, (HL)
+func OpCbBitBMemHL(cpu *CPU, instr *Instruction) int {
+	bitNum := cbBitIndex(instr)
+	addr := cpu.Registers.getHL()
+	value := cpu.Mmu.ReadByteAt(addr)
+	bitVal := (value >> bitNum) & 1
+
+	cpu.Registers.setFlag(ZeroFlag, bitVal == 0)
+	cpu.Registers.setFlag(SubtractFlag, false)
+	cpu.Registers.setFlag(HalfCarryFlag, true)
+	// Carry flag is preserved
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X bit%d=%d ; %s",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		value,
+		bitNum,
+		bitVal,
+		cpu.Registers.flagsString(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCbResBR8 Handles RES b, r
+func OpCbResBR8(cpu *CPU, instr *Instruction) int {
+	bitNum := cbBitIndex(instr)
+	regName := instr.Operands[1].Name
+	getter := cpu.Registers.getRegisterGetter8(regName)
+	setter := cpu.Registers.getRegisterSetter8(regName)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("OpCbResBR8: Unexpected register %q", regName))
+	}
+
+	old := getter()
+	result := old &^ (1 << bitNum)
+	setter(result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; %s=0x%02X → 0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		regName,
+		old,
+		result,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCbResBMemHL Handles RES b, (HL)
+func OpCbResBMemHL(cpu *CPU, instr *Instruction) int {
+	bitNum := cbBitIndex(instr)
+	addr := cpu.Registers.getHL()
+	old := cpu.Mmu.ReadByteAt(addr)
+	result := old &^ (1 << bitNum)
+	cpu.Mmu.WriteByteAt(addr, result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → 0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		old,
+		result,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCbSetBR8 Handles SET b, r
+func OpCbSetBR8(cpu *CPU, instr *Instruction) int {
+	bitNum := cbBitIndex(instr)
+	regName := instr.Operands[1].Name
+	getter := cpu.Registers.getRegisterGetter8(regName)
+	setter := cpu.Registers.getRegisterSetter8(regName)
+	if getter == nil || setter == nil {
+		panic(fmt.Sprintf("OpCbSetBR8: Unexpected register %q", regName))
+	}
+
+	old := getter()
+	result := old | (1 << bitNum)
+	setter(result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; %s=0x%02X → 0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		regName,
+		old,
+		result,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpCbSetBMemHL Handles SET b, (HL)
+func OpCbSetBMemHL(cpu *CPU, instr *Instruction) int {
+	bitNum := cbBitIndex(instr)
+	addr := cpu.Registers.getHL()
+	old := cpu.Mmu.ReadByteAt(addr)
+	result := old | (1 << bitNum)
+	cpu.Mmu.WriteByteAt(addr, result)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; [HL=0x%04X]=0x%02X → 0x%02X",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+		addr,
+		old,
+		result,
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpDi Handles DI, disabling interrupts immediately
+func OpDi(cpu *CPU, instr *Instruction) int {
+	cpu.ime = false
+	cpu.imeScheduled = false
+
+	log.Printf(
+		"0x%04X:\t%-12s ; IME=false",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpEi Handles EI, enabling interrupts after the next instruction
+func OpEi(cpu *CPU, instr *Instruction) int {
+	if !cpu.ime {
+		cpu.imeScheduled = true
+	}
+
+	log.Printf(
+		"0x%04X:\t%-12s ; IME scheduled",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpReti Handles RETI, returning and enabling interrupts without delay
+func OpReti(cpu *CPU, instr *Instruction) int {
+	from := cpu.opcodeAddr(instr)
+	addr := cpu.popWord()
+	cpu.Registers.setPC(addr)
+	cpu.ime = true
+	cpu.imeScheduled = false
+
+	log.Printf(
+		"0x%04X:\t%-12s ; popped PC=0x%04X; SP=0x%04X; IME=true",
+		from,
+		instr.String(),
+		addr,
+		cpu.Registers.getSP(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpHalt Handles HALT, suspending the CPU until an interrupt is pending
+func OpHalt(cpu *CPU, instr *Instruction) int {
+	if cpu.imeScheduled && cpu.Mmu.pendingInterrupts() != 0 {
+		// EI; HALT: IME is set right after and the interrupt is serviced at
+		// once, no HALT bug. The pushed address is HALT's, it runs again after RETI.
+		addr := cpu.opcodeAddr(instr)
+		cpu.Registers.setPC(addr)
+		log.Printf(
+			"0x%04X:\t%-12s ; EI with pending interrupt → serviced, returns to HALT",
+			addr,
+			instr.String(),
+		)
+		return instr.Cycles[0]
+	}
+	if !cpu.ime && cpu.Mmu.pendingInterrupts() != 0 {
+		// HALT bug: the CPU does not halt and fails to increment PC on the next fetch
+		cpu.haltBug = true
+		log.Printf(
+			"0x%04X:\t%-12s ; IME=false with pending interrupt → HALT bug",
+			cpu.opcodeAddr(instr),
+			instr.String(),
+		)
+		return instr.Cycles[0]
+	}
+	cpu.halted = true
+
+	log.Printf(
+		"0x%04X:\t%-12s ; halted",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+	)
+
+	return instr.Cycles[0]
+}
+
+// OpStop Handles STOP, switching CPU speed if KEY1 is armed, otherwise entering
+// the stop mode until a joypad line goes low
+func OpStop(cpu *CPU, instr *Instruction) int {
+	// The byte following STOP is skipped
+	cpu.fetchByte()
+
+	if cpu.Mmu.speedSwitchArmed {
+		cpu.Mmu.speedSwitchArmed = false
+		cpu.Mmu.doubleSpeed = !cpu.Mmu.doubleSpeed
+		cpu.Mmu.WriteByteAt(dividerReg, 0)
+		log.Printf(
+			"0x%04X:\t%-12s ; speed switch → double speed=%t",
+			cpu.opcodeAddr(instr),
+			instr.String(),
+			cpu.Mmu.doubleSpeed,
+		)
+		return speedSwitchCycles
+	}
+
+	cpu.stopped = true
+	cpu.Mmu.WriteByteAt(dividerReg, 0)
+
+	log.Printf(
+		"0x%04X:\t%-12s ; stopped, DIV reset",
+		cpu.opcodeAddr(instr),
+		instr.String(),
+	)
+
+	return instr.Cycles[0]
+}
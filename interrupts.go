@@ -0,0 +1,76 @@
+package main
+
+import "log"
+
+const (
+	interruptFlagReg   = 0xFF0F // IF
+	interruptEnableReg = 0xFFFF // IE
+)
+
+// Interrupt sources as bits of IE/IF, lowest bit has the highest priority
+const (
+	VBlankInterrupt uint8 = 1 << 0
+	StatInterrupt   uint8 = 1 << 1
+	TimerInterrupt  uint8 = 1 << 2
+	SerialInterrupt uint8 = 1 << 3
+	JoypadInterrupt uint8 = 1 << 4
+
+	interruptMask uint8 = 0x1F
+)
+
+const (
+	interruptVectorBase = 0x0040 // VBlank vector, each next source is 8 bytes further
+	interruptCycles     = 20     // 2 wait M-cycles, 2 to push PC, 1 to jump
+)
+
+var interruptNames = [5]string{"VBlank", "STAT", "Timer", "Serial", "Joypad"}
+
+// RequestInterrupt raises the given source(s) in IF
+func (mmu *MMU) RequestInterrupt(mask uint8) {
+	mmu.interruptFlag |= mask & interruptMask
+}
+
+// pendingInterrupts returns the sources both requested and enabled
+func (mmu *MMU) pendingInterrupts() uint8 {
+	return mmu.interruptFlag & mmu.interruptEnable & interruptMask
+}
+
+// serviceInterrupt dispatches the highest priority pending interrupt when IME
+// is set, returning the cycles spent or 0 if nothing was serviced.
+func (cpu *CPU) serviceInterrupt() int {
+	if !cpu.ime {
+		return 0
+	}
+	pending := cpu.Mmu.pendingInterrupts()
+	if pending == 0 {
+		return 0
+	}
+
+	for bit := range len(interruptNames) {
+		mask := uint8(1) << bit
+		if pending&mask == 0 {
+			continue
+		}
+
+		cpu.ime = false
+		cpu.haltBug = false // The handler's first opcode is fetched normally
+		cpu.Mmu.interruptFlag &^= mask
+
+		retAddr := cpu.Registers.getPC()
+		vector := uint16(interruptVectorBase + 8*bit)
+		cpu.pushWord(retAddr)
+		cpu.Registers.setPC(vector)
+
+		log.Printf(
+			"0x%04X:\t%-12s ; %s → PC=0x%04X; pushed ret=0x%04X; SP=0x%04X",
+			retAddr,
+			"INT",
+			interruptNames[bit],
+			vector,
+			retAddr,
+			cpu.Registers.getSP(),
+		)
+		return interruptCycles
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package main
+
+// Button is a set of Game Boy buttons, combined with |
+type Button uint8
+
+const (
+	ButtonRight Button = 1 << iota
+	ButtonLeft
+	ButtonUp
+	ButtonDown
+	ButtonA
+	ButtonB
+	ButtonSelect
+	ButtonStart
+)
+
+// P1/JOYP selection bits, a 0 selects the group
+const (
+	joypadSelectDpad    uint8 = 1 << 4
+	joypadSelectButtons uint8 = 1 << 5
+	joypadSelectMask          = joypadSelectDpad | joypadSelectButtons
+)
+
+// Joypad is the P1/JOYP register. Both button groups share the four input
+// lines P10–P13, which read 0 while a button of a selected group is pressed.
+type Joypad struct {
+	selected uint8 // Bits 4–5 as last written
+	pressed  Button
+
+	requestInterrupt func(mask uint8)
+}
+
+func NewJoypad(requestInterrupt func(mask uint8)) *Joypad {
+	return &Joypad{selected: joypadSelectMask, requestInterrupt: requestInterrupt}
+}
+
+// SetButtons replaces the set of pressed buttons
+func (j *Joypad) SetButtons(state Button) {
+	before := j.lines()
+	j.pressed = state
+	j.checkInterrupt(before)
+}
+
+// Buttons returns the set of pressed buttons
+func (j *Joypad) Buttons() Button {
+	return j.pressed
+}
+
+// lines returns P10–P13, active low
+func (j *Joypad) lines() uint8 {
+	var low uint8
+	if j.selected&joypadSelectDpad == 0 {
+		low |= uint8(j.pressed) & 0x0F
+	}
+	if j.selected&joypadSelectButtons == 0 {
+		low |= uint8(j.pressed) >> 4
+	}
+	return ^low & 0x0F
+}
+
+// checkInterrupt requests the joypad interrupt when a line goes from high to low
+func (j *Joypad) checkInterrupt(before uint8) {
+	if before&^j.lines() != 0 {
+		j.requestInterrupt(JoypadInterrupt)
+	}
+}
+
+func (j *Joypad) Read() uint8 {
+	// Bits 6–7 are unused and always read as 1
+	return 0xC0 | j.selected | j.lines()
+}
+
+func (j *Joypad) Write(value uint8) {
+	before := j.lines()
+	j.selected = value & joypadSelectMask
+	j.checkInterrupt(before)
+}
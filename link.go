@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// DefaultLinkQuantum is the number of normal speed T-cycles between two link
+// syncs, the time it takes to shift one byte with the internal clock
+const DefaultLinkQuantum = 8 * serialBitCycles
+
+// Link packet flags
+const (
+	linkMaster     uint8 = 1 << 0 // Internal clock transfer finished shifting, SB is sent
+	linkSlaveReady uint8 = 1 << 1 // External clock transfer waiting for the other side
+)
+
+const linkPacketSize = 10
+
+// linkPacket is the state one side sends at every sync
+type linkPacket struct {
+	stamp uint64 // Emulated normal speed T-cycles since the link was connected
+	flags uint8
+	sb    uint8
+}
+
+// Link connects the serial ports of two emulators. Both sides run freely for
+// a quantum of cycles, then swap a cycle-stamped packet and resolve transfers
+// at that boundary, so the outcome only depends on the emulated programs and
+// not on host scheduling. The side that listened (or is first in a pair)
+// writes first, the other reads first.
+type Link struct {
+	conn    io.ReadWriteCloser
+	first   bool
+	quantum int
+	cycles  int
+	stamp   uint64
+	serial  *Serial
+	closed  bool
+}
+
+// NewLink wraps an established connection; exactly one side must be first
+func NewLink(conn io.ReadWriteCloser, first bool) *Link {
+	return &Link{conn: conn, first: first, quantum: DefaultLinkQuantum}
+}
+
+// NewLinkPair returns two ends connected in memory, for two emulators in one
+// process. The pipe is synchronous: every sync blocks until the peer reaches
+// the same stamp, so each emulator must run on its own goroutine.
+func NewLinkPair() (*Link, *Link) {
+	a, b := net.Pipe()
+	return NewLink(a, true), NewLink(b, false)
+}
+
+// ListenLink waits for one peer on addr and returns the connected link.
+// Addresses starting with "unix:" are Unix sockets, anything else is TCP.
+func ListenLink(addr string) (*Link, error) {
+	network, address := linkNetwork(addr)
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("link: %w", err)
+	}
+	defer l.Close()
+
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("link: %w", err)
+	}
+	return NewLink(conn, true), nil
+}
+
+// DialLink connects to a peer waiting in ListenLink
+func DialLink(addr string) (*Link, error) {
+	network, address := linkNetwork(addr)
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("link: %w", err)
+	}
+	return NewLink(conn, false), nil
+}
+
+func linkNetwork(addr string) (string, string) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+// SetQuantum changes the cycles between syncs; both sides must use the same value
+func (l *Link) SetQuantum(cycles int) {
+	l.quantum = cycles
+}
+
+// Connected reports whether the peer is still there
+func (l *Link) Connected() bool {
+	return !l.closed
+}
+
+func (l *Link) Close() error {
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	return l.conn.Close()
+}
+
+// Tick advances the link clock by cycles at normal speed, syncing with the peer
+// at every quantum. Stamps then measure the same time on both sides even when
+// only one of them runs in CGB double speed.
+func (l *Link) Tick(cycles int) {
+	if l.closed {
+		return
+	}
+	l.cycles += cycles
+	for l.cycles >= l.quantum && !l.closed {
+		l.cycles -= l.quantum
+		l.stamp += uint64(l.quantum)
+		l.sync()
+	}
+}
+
+func (l *Link) sync() {
+	s := l.serial
+	local := linkPacket{stamp: l.stamp, sb: s.sb}
+	switch {
+	case s.shifted:
+		local.flags |= linkMaster
+	case s.transferring() && s.sc&serialInternalClock == 0:
+		local.flags |= linkSlaveReady
+	}
+
+	peer, err := l.exchange(local)
+	if err == nil && peer.stamp != local.stamp {
+		err = fmt.Errorf("out of sync at cycle %d, peer is at %d", local.stamp, peer.stamp)
+	}
+	if err != nil {
+		fmt.Printf("Warning: link disconnected: %v\n", err)
+		l.Close()
+		if s.shifted {
+			s.finish(0xFF)
+		}
+		return
+	}
+
+	switch {
+	case local.flags&linkMaster != 0:
+		// A peer not transferring does not shift, the line stays high
+		in := uint8(0xFF)
+		if peer.flags&(linkMaster|linkSlaveReady) != 0 {
+			in = peer.sb
+		}
+		s.finish(in)
+	case local.flags&linkSlaveReady != 0 && peer.flags&linkMaster != 0:
+		s.finish(peer.sb)
+	}
+}
+
+func (l *Link) exchange(local linkPacket) (linkPacket, error) {
+	if l.first {
+		if err := l.send(local); err != nil {
+			return linkPacket{}, err
+		}
+		return l.receive()
+	}
+	peer, err := l.receive()
+	if err != nil {
+		return linkPacket{}, err
+	}
+	return peer, l.send(local)
+}
+
+func (l *Link) send(p linkPacket) error {
+	var buf [linkPacketSize]byte
+	binary.BigEndian.PutUint64(buf[0:8], p.stamp)
+	buf[8] = p.flags
+	buf[9] = p.sb
+	_, err := l.conn.Write(buf[:])
+	return err
+}
+
+func (l *Link) receive() (linkPacket, error) {
+	var buf [linkPacketSize]byte
+	if _, err := io.ReadFull(l.conn, buf[:]); err != nil {
+		return linkPacket{}, err
+	}
+	return linkPacket{
+		stamp: binary.BigEndian.Uint64(buf[0:8]),
+		flags: buf[8],
+		sb:    buf[9],
+	}, nil
+}
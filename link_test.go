@@ -0,0 +1,127 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// linkedSerial is one side of a NewLinkPair test, recording the link stamp at
+// which its serial interrupt was raised
+type linkedSerial struct {
+	serial   *Serial
+	link     *Link
+	doneAt   uint64
+	finished bool
+}
+
+func newLinkedSerial(l *Link, sb, sc uint8) *linkedSerial {
+	ls := &linkedSerial{link: l}
+	ls.serial = NewSerial(nil, func(mask uint8) {
+		if mask&SerialInterrupt != 0 {
+			ls.doneAt = l.stamp
+			ls.finished = true
+		}
+	})
+	ls.serial.link = l
+	l.serial = ls.serial
+	ls.serial.WriteRegister(serialDataReg, sb)
+	ls.serial.WriteRegister(serialControlReg, sc)
+	return ls
+}
+
+// run ticks the side in M-cycles for the given number of link quanta
+func (ls *linkedSerial) run(quanta int) {
+	for range quanta * DefaultLinkQuantum / 4 {
+		ls.serial.Tick(4)
+		ls.link.Tick(4)
+	}
+}
+
+// runPair runs both sides on their own goroutines, as the synchronous pipe needs
+func runPair(t *testing.T, a, b *linkedSerial, quanta int) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		b.run(quanta)
+		done <- struct{}{}
+	}()
+	go func() {
+		a.run(quanta)
+		done <- struct{}{}
+	}()
+	for range 2 {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("link pair deadlocked")
+		}
+	}
+}
+
+func TestLinkPairTransfer(t *testing.T) {
+	tests := []struct {
+		name      string
+		masterSC  uint8
+		slaveSC   uint8 // Slave transfer, or 0 for a peer not transferring
+		wantIn    uint8 // SB of the master after the transfer
+		wantSlave uint8 // SB of the slave
+	}{
+		{"slave waiting", serialStart | serialInternalClock, serialStart, 0x34, 0x12},
+		{"peer not transferring", serialStart | serialInternalClock, 0, 0xFF, 0x34},
+	}
+	for _, tt := range tests {
+		for _, masterFirst := range []bool{true, false} {
+			a, b := NewLinkPair()
+			if !masterFirst {
+				a, b = b, a
+			}
+			master := newLinkedSerial(a, 0x12, tt.masterSC)
+			slave := newLinkedSerial(b, 0x34, tt.slaveSC)
+			runPair(t, master, slave, 3)
+
+			if master.serial.sb != tt.wantIn || slave.serial.sb != tt.wantSlave {
+				t.Errorf("%s, master first=%v: master SB=%02X slave SB=%02X, want %02X and %02X",
+					tt.name, masterFirst, master.serial.sb, slave.serial.sb, tt.wantIn, tt.wantSlave)
+			}
+			// Eight bits at 8192 Hz end at the first sync
+			if !master.finished || master.doneAt != DefaultLinkQuantum || master.serial.transferring() {
+				t.Errorf("%s, master first=%v: master finished=%v at stamp %d, want at %d",
+					tt.name, masterFirst, master.finished, master.doneAt, DefaultLinkQuantum)
+			}
+			if tt.slaveSC != 0 && (!slave.finished || slave.doneAt != master.doneAt) {
+				t.Errorf("%s, master first=%v: slave finished=%v at stamp %d, want with the master at %d",
+					tt.name, masterFirst, slave.finished, slave.doneAt, master.doneAt)
+			}
+			if tt.slaveSC == 0 && slave.finished {
+				t.Errorf("%s: idle peer got a serial interrupt", tt.name)
+			}
+			a.Close()
+			b.Close()
+		}
+	}
+}
+
+func TestLinkPairDisconnect(t *testing.T) {
+	a, b := NewLinkPair()
+	master := newLinkedSerial(a, 0x12, serialStart|serialInternalClock)
+	b.Close()
+	master.run(1)
+	if a.Connected() {
+		t.Error("link still connected after the peer closed")
+	}
+	if !master.finished || master.serial.sb != 0xFF {
+		t.Errorf("master finished=%v SB=%02X, want the transfer ended with FF", master.finished, master.serial.sb)
+	}
+}
+
+func TestLinkStampsNormalSpeedCycles(t *testing.T) {
+	a, b := NewLinkPair()
+	defer b.Close()
+	defer a.Close()
+	mmu, _ := NewMMU(nil, WithLink(a))
+	mmu.doubleSpeed = true
+	mmu.Tick(8)
+	if a.cycles != 4 {
+		t.Errorf("8 double speed cycles advanced the link by %d, want 4", a.cycles)
+	}
+}
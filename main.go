@@ -3,14 +3,101 @@
 package main
 
 import (
+	"bytes"
 	_ "embed"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AlessandroGrassi99/gb-emulator/cartridge"
 )
 
 //go:embed data/dmg_boot.bin
 var bootROM []byte
 
+const pollCycles = 70224 // One frame, host events are checked in between
+
 func main() {
+	saveInterval := flag.Duration("save-interval", 10*time.Second, "how often battery RAM is flushed to the .sav file while running (0 disables)")
+	renderer := flag.String("renderer", "scanline", "PPU renderer: \"scanline\" or \"fifo\" for mid-line accurate rendering")
+	colorCorrection := flag.Bool("color-correction", false, "show CGB colours as the LCD would instead of raw RGB555")
+	compatPalette := flag.String("compat-palette", "", "colour DMG games like CGB hardware: \""+CompatAuto+"\" picks the palette from the title as the CGB boot ROM does, or a boot button combination: "+strings.Join(CompatPaletteNames()[1:], ", "))
+	serialOut := flag.String("serial", "", "where bytes sent over the link port go: \"stdout\" or empty to drop them")
+	expect := flag.String("expect", "", "exit with status 0 once the link port output contains this text, e.g. \"Passed\" for test ROMs")
+	failText := flag.String("fail", "Failed", "with -expect, exit with status 1 once the link port output contains this text")
+	maxFrames := flag.Int("frames", 0, "stop after this many frames (0 runs until interrupted), with -expect this is a failure")
+	maxCycles := flag.Uint64("cycles", 0, "stop after this many T-cycles at normal speed (0 runs until interrupted), checked between instructions so the run can overshoot, e.g. by a VRAM DMA stall")
+	wavPath := flag.String("wav", "", "record the audio output to this 16-bit stereo WAV file, bound the run with -frames or -cycles")
+	sampleRate := flag.Int("sample-rate", DefaultSampleRate, "audio sample rate in Hz")
+	printerDir := flag.String("printer", "", "attach a Game Boy Printer to the link port, writing printed sheets as PNG files to this directory")
+	linkListen := flag.String("link-listen", "", "wait for a second emulator on this address (host:port or unix:/path) and link their serial ports")
+	linkDial := flag.String("link-dial", "", "link the serial port to an emulator listening on this address (host:port or unix:/path)")
+	trace := flag.Bool("trace", true, "log every executed instruction")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <rom.gb|rom.gbc>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var renderMode RenderMode
+	switch *renderer {
+	case "scanline":
+		renderMode = RenderScanline
+	case "fifo":
+		renderMode = RenderFIFO
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown renderer %q\n", *renderer)
+		os.Exit(2)
+	}
+
+	if *compatPalette != "" && !slices.Contains(CompatPaletteNames(), strings.ToLower(*compatPalette)) {
+		fmt.Fprintf(os.Stderr, "Unknown compatibility palette %q\n", *compatPalette)
+		os.Exit(2)
+	}
+
+	if *sampleRate <= 0 {
+		fmt.Fprintf(os.Stderr, "Invalid sample rate %d\n", *sampleRate)
+		os.Exit(2)
+	}
+
+	if !*trace {
+		log.SetOutput(io.Discard)
+	}
+
+	var serialWriters []io.Writer
+	if *serialOut == "stdout" {
+		serialWriters = append(serialWriters, os.Stdout)
+	} else if *serialOut != "" {
+		fmt.Fprintf(os.Stderr, "Unknown serial output %q\n", *serialOut)
+		os.Exit(2)
+	}
+	var serialLog bytes.Buffer
+	if *expect != "" {
+		serialWriters = append(serialWriters, &serialLog)
+	}
+
+	cart, err := cartridge.Load(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading cartridge: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cart.VerifyGlobalChecksum(); errors.Is(err, cartridge.ErrGlobalChecksum) {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	fmt.Printf("Loaded %s\n", cart.Header)
+
 	for idx := range 512 {
 		opFunc, ok := opcodesFunc[idx]
 		if !ok {
@@ -20,17 +107,157 @@ func main() {
 		}
 	}
 
+	mmuOpts := []Option{WithRenderMode(renderMode), WithColorCorrection(*colorCorrection)}
+	if *compatPalette != "" {
+		mmuOpts = append(mmuOpts, WithCompatPalette(*compatPalette))
+	}
+	var printer *Printer
+	switch {
+	case *printerDir != "" && len(serialWriters) > 0:
+		fmt.Fprintln(os.Stderr, "The printer and -serial/-expect cannot share the link port")
+		os.Exit(2)
+	case *printerDir != "" && (*linkListen != "" || *linkDial != ""):
+		fmt.Fprintln(os.Stderr, "The printer and -link-listen/-link-dial cannot share the link port")
+		os.Exit(2)
+	case *printerDir != "":
+		printer = NewPrinter(*printerDir)
+		mmuOpts = append(mmuOpts, WithSerialDevice(printer))
+	case len(serialWriters) > 0:
+		mmuOpts = append(mmuOpts, WithSerialDevice(NewSerialWriter(io.MultiWriter(serialWriters...))))
+	}
+	var wav *WAVWriter
+	if *wavPath != "" {
+		wav, err = CreateWAV(*wavPath, *sampleRate)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating WAV file: %v\n", err)
+			os.Exit(1)
+		}
+		mmuOpts = append(mmuOpts, WithAudio(wav, *sampleRate))
+	}
+
+	var link *Link
+	switch {
+	case *linkListen != "" && *linkDial != "":
+		fmt.Fprintln(os.Stderr, "Only one of -link-listen and -link-dial can be used")
+		os.Exit(2)
+	case *linkListen != "":
+		fmt.Printf("Waiting for link peer on %s...\n", *linkListen)
+		link, err = ListenLink(*linkListen)
+	case *linkDial != "":
+		link, err = DialLink(*linkDial)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting link cable: %v\n", err)
+		os.Exit(1)
+	}
+	if link != nil {
+		defer link.Close()
+		mmuOpts = append(mmuOpts, WithLink(link))
+	}
+
+	mmu, err := NewMMU(cart, mmuOpts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up the Game Boy: %v\n", err)
+		os.Exit(1)
+	}
+
 	cpu := &CPU{
-		Mmu: NewMMU(),
+		Mmu: mmu,
 		Registers: &Registers{
 			PC: 0x0000,
 			SP: 0xFFFE,
 		},
 	}
 
+	if mmu.CGB() {
+		// Only the DMG boot ROM is embedded, start from the CGB post-boot state
+		fmt.Println("CGB mode")
+		cpu.Registers = cgbBootRegisters()
+		mmu.skipBootROM()
+	}
+
+	var saver *cartridge.Saver
+	if battery, ok := mmu.mbc.(cartridge.Battery); ok && cart.Header.Type.HasBattery() {
+		saver = cartridge.NewSaver(cartridge.SavePath(cart.Path), battery)
+		if err := saver.Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading save: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	lastFlush := time.Now()
+
+	// shutdown persists everything that outlives the process
+	shutdown := func() {
+		flushSave(saver)
+		flushPrinter(printer)
+		if wav != nil {
+			mmu.apu.Flush()
+			if err := wav.Close(); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+		}
+	}
+
 	fmt.Println("Starting emulation...")
-	for {
-		cpu.Step()
-		//time.Sleep(100 * time.Millisecond)
+	var total uint64
+	for frame := 1; ; frame++ {
+		for cycles := 0; cycles < pollCycles && (*maxCycles == 0 || total < *maxCycles); {
+			elapsed := mmu.Tick(cpu.Step())
+			cycles += elapsed
+			total += uint64(elapsed)
+		}
+
+		select {
+		case <-stop:
+			fmt.Println("Stopping emulation...")
+			shutdown()
+			return
+		default:
+		}
+
+		if *expect != "" {
+			switch {
+			case bytes.Contains(serialLog.Bytes(), []byte(*expect)):
+				fmt.Printf("\nPASS after %d frames\n", frame)
+				shutdown()
+				return
+			case *failText != "" && bytes.Contains(serialLog.Bytes(), []byte(*failText)):
+				fmt.Printf("\nFAIL after %d frames\n", frame)
+				shutdown()
+				os.Exit(1)
+			}
+		}
+		if (*maxFrames > 0 && frame >= *maxFrames) || (*maxCycles > 0 && total >= *maxCycles) {
+			shutdown()
+			if *expect != "" {
+				fmt.Printf("\nFAIL: %q not seen after %d frames\n", *expect, frame)
+				os.Exit(1)
+			}
+			return
+		}
+
+		if *saveInterval > 0 && time.Since(lastFlush) >= *saveInterval {
+			flushSave(saver)
+			lastFlush = time.Now()
+		}
+	}
+}
+
+func flushSave(saver *cartridge.Saver) {
+	if saver == nil {
+		return
+	}
+	if err := saver.Flush(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// flushPrinter writes a sheet still waiting for its paper feed
+func flushPrinter(printer *Printer) {
+	if printer != nil {
+		printer.Flush()
 	}
 }
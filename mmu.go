@@ -1,29 +1,213 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/AlessandroGrassi99/gb-emulator/cartridge"
+)
 
 const (
+	joypadReg      = 0xFF00
 	bootDisableReg = 0xFF50
 )
 
 type MMU struct {
-	memory      [0x10000]byte // 64KB address space
+	memory      [0x10000]byte // 64KB address space, WRAM and the peripherals are mapped over it
+	wram        [8][0x1000]byte
+	wramBank    int           // SVBK, bank at 0xD000–0xDFFF (always 1 on DMG)
 	boot        [0x00100]byte // 256B address space
 	bootEnabled bool
+	cart        *cartridge.Cartridge
+	mbc         cartridge.MBC
+	ppu         *PPU
+	dma         oamDMA
+	hdma        hdma
+	stallCycles int // CPU cycles to stay halted for a VRAM DMA
+	stepCycles  int // T-cycles into the current CPU step, one M-cycle per memory access
+	timerCycles int // T-cycles of the current step the timer has already run
+	timer       *Timer
+	joypad      *Joypad
+	serial      *Serial
+	apu         *APU
+
+	interruptFlag   uint8 // IF (0xFF0F)
+	interruptEnable uint8 // IE (0xFFFF)
+
+	cgb              bool
+	speedSwitchArmed bool // KEY1 bit 0, a STOP switches speed
+	doubleSpeed      bool // KEY1 bit 7
+	infrared         uint8
+	undocumented     [4]uint8
+}
+
+// Option configures the hardware built by NewMMU
+type Option func(*options)
+
+type options struct {
+	renderMode RenderMode
+	mbcOptions []cartridge.Option
+	serial     SerialDevice
+	link       *Link
+	audio      AudioSink
+	sampleRate int
+
+	colorCorrection bool
+	compatPalette   string
+}
+
+// WithRenderMode selects the PPU renderer, RenderScanline by default
+func WithRenderMode(mode RenderMode) Option {
+	return func(o *options) {
+		o.renderMode = mode
+	}
+}
+
+// WithColorCorrection maps CGB colours through a curve approximating the LCD
+// instead of scaling RGB555 linearly
+func WithColorCorrection(enabled bool) Option {
+	return func(o *options) {
+		o.colorCorrection = enabled
+	}
+}
+
+// WithCompatPalette runs DMG games on CGB hardware, coloured by the named
+// palette: CompatAuto or a boot ROM button combination such as "left+a"
+func WithCompatPalette(name string) Option {
+	return func(o *options) {
+		o.compatPalette = name
+	}
+}
+
+// WithSerialDevice plugs a device into the link port, by default nothing is connected
+func WithSerialDevice(device SerialDevice) Option {
+	return func(o *options) {
+		o.serial = device
+	}
+}
+
+// WithLink connects the link port to another emulator instead of a serial device
+func WithLink(link *Link) Option {
+	return func(o *options) {
+		o.link = link
+	}
 }
 
-func NewMMU() *MMU {
-	m := &MMU{bootEnabled: true}
+// WithAudio sends the APU output to sink at sampleRate Hz (DefaultSampleRate if 0)
+func WithAudio(sink AudioSink, sampleRate int) Option {
+	return func(o *options) {
+		o.audio = sink
+		o.sampleRate = sampleRate
+	}
+}
+
+// WithMBCOptions passes options to the memory bank controller, e.g. to observe the rumble motor
+func WithMBCOptions(opts ...cartridge.Option) Option {
+	return func(o *options) {
+		o.mbcOptions = append(o.mbcOptions, opts...)
+	}
+}
+
+// NewMMU maps the cartridge through the controller picked from its header type
+func NewMMU(cart *cartridge.Cartridge, opts ...Option) (*MMU, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	m := &MMU{bootEnabled: true, cart: cart, wramBank: 1}
+	m.cgb = cart != nil && cart.Header.CGBSupported()
 	copy(m.boot[:], bootROM)
-	return m
+	m.ppu = NewPPU(m.RequestInterrupt, o.renderMode, m.cgb)
+	m.ppu.colorCorrection = o.colorCorrection
+	if m.cgb {
+		m.ppu.onHBlank = m.hblankDMA
+	} else if o.compatPalette != "" && cart != nil {
+		colors, err := compatColors(o.compatPalette, &cart.Header, o.colorCorrection)
+		if err != nil {
+			return nil, err
+		}
+		m.ppu.dmgColors = colors
+	}
+	m.timer = NewTimer(m.RequestInterrupt)
+	m.joypad = NewJoypad(m.RequestInterrupt)
+	m.serial = NewSerial(o.serial, m.RequestInterrupt)
+	m.serial.cgb = m.cgb
+	m.apu = NewAPU(o.audio, o.sampleRate)
+	m.apu.cgb = m.cgb
+	if o.link != nil {
+		m.serial.link = o.link
+		o.link.serial = m.serial
+	}
+
+	if cart != nil {
+		mbc, err := cartridge.NewMBC(cart, o.mbcOptions...)
+		if err != nil {
+			return nil, err
+		}
+		m.mbc = mbc
+	}
+	return m, nil
 }
 
+// ReadByteAt reads a byte as seen by the CPU
 func (mmu *MMU) ReadByteAt(addr uint16) uint8 {
+	mmu.access(addr)
+	if mmu.dmaBlocksBus(addr) {
+		return 0xFF
+	}
+	return mmu.read(addr)
+}
+
+// read serves an address without the bus restrictions of an OAM DMA
+func (mmu *MMU) read(addr uint16) uint8 {
 	// While enabled, 0x0000–0x00FF is served by the internal ROM
 	if mmu.bootEnabled && addr < 0x0100 {
 		return mmu.boot[addr]
 	}
 
+	// 0x0000–0x7FFF and 0xA000–0xBFFF are served by the cartridge
+	if isCartridgeAddr(addr) {
+		if mmu.mbc == nil {
+			return 0xFF
+		}
+		return mmu.mbc.ReadByteAt(addr)
+	}
+
+	switch {
+	case isWRAMAddr(addr):
+		bank, offset := mmu.wramAddr(addr)
+		return mmu.wram[bank][offset]
+	case isCGBRegister(addr):
+		if !mmu.cgb {
+			return 0xFF
+		}
+		return mmu.readCGBRegister(addr)
+	case isVRAMAddr(addr):
+		return mmu.ppu.ReadVRAM(addr)
+	case isOAMAddr(addr):
+		return mmu.ppu.ReadOAM(addr)
+	case isPPURegister(addr):
+		return mmu.ppu.ReadRegister(addr)
+	case isTimerRegister(addr):
+		return mmu.timer.ReadRegister(addr)
+	case isAPUAddr(addr):
+		return mmu.apu.ReadRegister(addr)
+	}
+
+	switch addr {
+	case joypadReg:
+		return mmu.joypad.Read()
+	case serialDataReg, serialControlReg:
+		return mmu.serial.ReadRegister(addr)
+	case interruptFlagReg:
+		// Upper 3 bits are unused and always read as 1
+		return mmu.interruptFlag | ^interruptMask
+	case interruptEnableReg:
+		return mmu.interruptEnable
+	case dmaReg:
+		return mmu.dma.reg
+	}
+
 	if int(addr) >= len(mmu.memory) {
 		fmt.Printf("Warning: Read memory out of bounds at 0x%04X\n", addr)
 		return 0xFF
@@ -38,6 +222,10 @@ func (mmu *MMU) ReadWordAt(addr uint16) uint16 {
 }
 
 func (mmu *MMU) WriteByteAt(addr uint16, value uint8) {
+	mmu.access(addr)
+	if mmu.dmaBlocksBus(addr) {
+		return
+	}
 	// Permanently switch boot ROM out of 0x0000‑0x00FF
 	if addr == bootDisableReg {
 		// Latch boot ROM off permanently if bit0 == 1
@@ -48,9 +236,124 @@ func (mmu *MMU) WriteByteAt(addr uint16, value uint8) {
 		mmu.memory[addr] = value
 		return
 	}
+	// ROM writes program the memory bank controller
+	if isCartridgeAddr(addr) {
+		if mmu.mbc != nil {
+			mmu.mbc.WriteByteAt(addr, value)
+		}
+		return
+	}
+	switch {
+	case isWRAMAddr(addr):
+		bank, offset := mmu.wramAddr(addr)
+		mmu.wram[bank][offset] = value
+		return
+	case isCGBRegister(addr):
+		if mmu.cgb {
+			mmu.writeCGBRegister(addr, value)
+		}
+		return
+	case isVRAMAddr(addr):
+		mmu.ppu.WriteVRAM(addr, value)
+		return
+	case isOAMAddr(addr):
+		mmu.ppu.WriteOAM(addr, value)
+		return
+	case isPPURegister(addr):
+		mmu.ppu.WriteRegister(addr, value)
+		return
+	case isTimerRegister(addr):
+		mmu.timer.WriteRegister(addr, value)
+		return
+	case isAPUAddr(addr):
+		mmu.apu.WriteRegister(addr, value)
+		return
+	}
+	switch addr {
+	case joypadReg:
+		mmu.joypad.Write(value)
+		return
+	case serialDataReg, serialControlReg:
+		mmu.serial.WriteRegister(addr, value)
+		return
+	case interruptFlagReg:
+		mmu.interruptFlag = value & interruptMask
+		return
+	case interruptEnableReg:
+		mmu.interruptEnable = value
+		return
+	case dmaReg:
+		mmu.startDMA(value)
+		return
+	}
 	if int(addr) >= len(mmu.memory) {
 		fmt.Printf("Warning: Write memory out of bounds at 0x%04X\n", addr)
 		return
 	}
 	mmu.memory[addr] = value
 }
+
+// Tick advances the peripherals by the cycles the CPU just spent
+// and returns the time elapsed in normal-speed cycles. In CGB double speed
+// the timer, serial port and OAM DMA follow the CPU clock while the PPU and
+// APU only advance by half.
+func (mmu *MMU) Tick(cycles int) int {
+	mmu.timer.Tick(max(0, cycles-mmu.timerCycles))
+	mmu.stepCycles, mmu.timerCycles = 0, 0
+	mmu.serial.Tick(cycles)
+	mmu.tickDMA(cycles)
+
+	elapsed := cycles
+	if mmu.doubleSpeed {
+		elapsed = cycles / 2
+	}
+	if mmu.serial.link != nil {
+		mmu.serial.link.Tick(elapsed)
+	}
+	mmu.ppu.Tick(elapsed)
+	mmu.apu.Tick(elapsed)
+	return elapsed
+}
+
+// access accounts one M-cycle for a CPU memory access. The timer is caught up
+// with the M-cycles before a timer register access, so TIMA reloads and DIV/TAC
+// glitches land on the right cycle within an instruction instead of after it.
+func (mmu *MMU) access(addr uint16) {
+	if isTimerRegister(addr) {
+		mmu.timer.Tick(mmu.stepCycles - mmu.timerCycles)
+		mmu.timerCycles = mmu.stepCycles
+	}
+	mmu.stepCycles += 4
+}
+
+// SetButtons updates the pressed buttons, typically once per frame from the host
+func (mmu *MMU) SetButtons(state Button) {
+	mmu.joypad.SetButtons(state)
+}
+
+// joypadLineLow reports whether any selected P10-P13 input line is pulled low
+func (mmu *MMU) joypadLineLow() bool {
+	return mmu.joypad.lines() != 0x0F
+}
+
+func isCartridgeAddr(addr uint16) bool {
+	return addr < 0x8000 || (addr >= 0xA000 && addr < 0xC000)
+}
+
+func isVRAMAddr(addr uint16) bool {
+	return addr >= 0x8000 && addr < 0xA000
+}
+
+func isOAMAddr(addr uint16) bool {
+	return addr >= 0xFE00 && addr < 0xFEA0
+}
+
+// isPPURegister reports whether addr is one of the LCD registers 0xFF40–0xFF4B,
+// except 0xFF46 which starts an OAM DMA
+func isPPURegister(addr uint16) bool {
+	return addr >= lcdcReg && addr <= wxReg && addr != dmaReg
+}
+
+func isTimerRegister(addr uint16) bool {
+	return addr >= dividerReg && addr <= tacReg
+}
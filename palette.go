@@ -0,0 +1,110 @@
+package main
+
+import "image/color"
+
+const (
+	bgPaletteSpecReg  = 0xFF68 // BCPS
+	bgPaletteDataReg  = 0xFF69 // BCPD
+	objPaletteSpecReg = 0xFF6A // OCPS
+	objPaletteDataReg = 0xFF6B // OCPD
+)
+
+// cgbPalettes is one of the two CGB palette RAMs: 8 palettes of 4 RGB555
+// colours, little-endian, accessed through a spec register holding the byte
+// index and the auto-increment flag
+type cgbPalettes struct {
+	data    [64]uint8
+	index   uint8
+	autoInc bool
+}
+
+func (c *cgbPalettes) readSpec() uint8 {
+	// Bit 6 is unused and always reads as 1
+	return boolToUint8(c.autoInc)<<7 | 0x40 | c.index
+}
+
+func (c *cgbPalettes) writeSpec(value uint8) {
+	c.index = value & 0x3F
+	c.autoInc = value&0x80 != 0
+}
+
+func (c *cgbPalettes) readData() uint8 {
+	return c.data[c.index]
+}
+
+// writeData stores a byte unless the PPU holds palette RAM; the index moves on either way
+func (c *cgbPalettes) writeData(value uint8, accessible bool) {
+	if accessible {
+		c.data[c.index] = value
+	}
+	if c.autoInc {
+		c.index = (c.index + 1) & 0x3F
+	}
+}
+
+// rgb555 returns colour index (0–3) of palette (0–7)
+func (c *cgbPalettes) rgb555(palette, index uint8) uint16 {
+	offset := int(palette&0x07)*8 + int(index)*2
+	return uint16(c.data[offset]) | uint16(c.data[offset+1])<<8
+}
+
+// rgb555ToRGBA expands a CGB colour to 8 bits per channel. With correction
+// the channels are mixed and darkened to approximate how the LCD shows them.
+func rgb555ToRGBA(c uint16, correct bool) color.RGBA {
+	r := int(c & 0x1F)
+	g := int(c >> 5 & 0x1F)
+	b := int(c >> 10 & 0x1F)
+
+	if !correct {
+		return color.RGBA{R: uint8(r<<3 | r>>2), G: uint8(g<<3 | g>>2), B: uint8(b<<3 | b>>2), A: 0xFF}
+	}
+
+	// Each weighted sum is at most 32×31, scaled down to 0–240 like the LCD peak
+	const scale = 32 * 31
+	cr := (r*26 + g*4 + b*2) * 240 / scale
+	cg := (g*24 + b*8) * 240 / scale
+	cb := (r*6 + g*4 + b*22) * 240 / scale
+	return color.RGBA{R: uint8(cr), G: uint8(cg), B: uint8(cb), A: 0xFF}
+}
+
+func isPaletteRegister(addr uint16) bool {
+	return addr >= bgPaletteSpecReg && addr <= objPaletteDataReg
+}
+
+// paletteAccessible reports whether the CPU can reach palette RAM (blocked in mode 3)
+func (p *PPU) paletteAccessible() bool {
+	return p.vramAccessible()
+}
+
+func (p *PPU) ReadPaletteRegister(addr uint16) uint8 {
+	switch addr {
+	case bgPaletteSpecReg:
+		return p.bgPalettes.readSpec()
+	case bgPaletteDataReg:
+		if !p.paletteAccessible() {
+			return 0xFF
+		}
+		return p.bgPalettes.readData()
+	case objPaletteSpecReg:
+		return p.objPalettes.readSpec()
+	case objPaletteDataReg:
+		if !p.paletteAccessible() {
+			return 0xFF
+		}
+		return p.objPalettes.readData()
+	}
+	return 0xFF
+}
+
+func (p *PPU) WritePaletteRegister(addr uint16, value uint8) {
+	switch addr {
+	case bgPaletteSpecReg:
+		p.bgPalettes.writeSpec(value)
+	case bgPaletteDataReg:
+		p.bgPalettes.writeData(value, p.paletteAccessible())
+	case objPaletteSpecReg:
+		p.objPalettes.writeSpec(value)
+	case objPaletteDataReg:
+		p.objPalettes.writeData(value, p.paletteAccessible())
+	}
+}
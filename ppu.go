@@ -0,0 +1,496 @@
+package main
+
+import (
+	"image"
+	"image/color"
+)
+
+const (
+	ScreenWidth  = 160
+	ScreenHeight = 144
+
+	dotsPerLine   = 456
+	linesPerFrame = 154
+	oamScanDots   = 80
+	drawingDots   = 172 // Minimum length of mode 3
+	DotsPerFrame  = dotsPerLine * linesPerFrame
+)
+
+const (
+	lcdcReg = 0xFF40 // LCD control
+	statReg = 0xFF41 // LCD status
+	scyReg  = 0xFF42 // Background scroll Y
+	scxReg  = 0xFF43 // Background scroll X
+	lyReg   = 0xFF44 // Current scanline
+	lycReg  = 0xFF45 // Scanline compare
+	bgpReg  = 0xFF47 // Background palette (DMG)
+	obp0Reg = 0xFF48 // Object palette 0 (DMG)
+	obp1Reg = 0xFF49 // Object palette 1 (DMG)
+	wyReg   = 0xFF4A // Window Y
+	wxReg   = 0xFF4B // Window X + 7
+)
+
+// LCDC bits
+const (
+	lcdcBGEnable      uint8 = 1 << 0 // BG and window enable (DMG)
+	lcdcOBJEnable     uint8 = 1 << 1
+	lcdcOBJSize       uint8 = 1 << 2 // 0: 8×8, 1: 8×16
+	lcdcBGTileMap     uint8 = 1 << 3 // 0: 0x9800, 1: 0x9C00
+	lcdcTileData      uint8 = 1 << 4 // 0: 0x8800 signed, 1: 0x8000 unsigned
+	lcdcWindowEnable  uint8 = 1 << 5
+	lcdcWindowTileMap uint8 = 1 << 6 // 0: 0x9800, 1: 0x9C00
+	lcdcEnable        uint8 = 1 << 7
+)
+
+// STAT bits
+const (
+	statModeMask     uint8 = 0x03
+	statLYCEqual     uint8 = 1 << 2
+	statHBlankSource uint8 = 1 << 3
+	statVBlankSource uint8 = 1 << 4
+	statOAMSource    uint8 = 1 << 5
+	statLYCSource    uint8 = 1 << 6
+	statWritable     uint8 = statHBlankSource | statVBlankSource | statOAMSource | statLYCSource
+)
+
+type ppuMode uint8
+
+const (
+	modeHBlank  ppuMode = 0
+	modeVBlank  ppuMode = 1
+	modeOAMScan ppuMode = 2
+	modeDrawing ppuMode = 3
+)
+
+// RenderMode selects how the PPU draws mode 3
+type RenderMode uint8
+
+const (
+	// RenderScanline draws each line in one go, fast and enough for most games
+	RenderScanline RenderMode = iota
+	// RenderFIFO emulates the pixel fetchers and FIFOs dot by dot, so mid-line
+	// register writes and the variable mode 3 length are reproduced
+	RenderFIFO
+)
+
+// lineRenderer draws the pixels of a scanline during mode 3
+type lineRenderer interface {
+	// startLine is called when mode 3 begins
+	startLine()
+	// drawDot advances one dot and reports whether the line is complete
+	drawDot() bool
+}
+
+// dmgShades maps the 2-bit shades of BGP/OBP0/OBP1 to output colours
+var dmgShades = [4]color.RGBA{
+	{0xFF, 0xFF, 0xFF, 0xFF},
+	{0xAA, 0xAA, 0xAA, 0xFF},
+	{0x55, 0x55, 0x55, 0xFF},
+	{0x00, 0x00, 0x00, 0xFF},
+}
+
+// Rows of PPU.dmgColors
+const (
+	dmgColorsBG = iota
+	dmgColorsOBJ0
+	dmgColorsOBJ1
+)
+
+// PPU is the picture processing unit. It is clocked in dots (T-cycles) and
+// renders one scanline at a time into a 160×144 framebuffer.
+type PPU struct {
+	vram [2][0x2000]byte // 0x8000–0x9FFF, bank 1 only on CGB
+	oam  [0xA0]byte      // 0xFE00–0xFE9F
+
+	vramBank int // VBK, the bank the CPU sees
+
+	cgb             bool
+	bgPalettes      cgbPalettes
+	objPalettes     cgbPalettes
+	opri            uint8 // OPRI bit 0: sprites ordered by X like DMG instead of OAM index
+	colorCorrection bool
+
+	// dmgColors maps DMG shades per BG, OBJ0 and OBJ1, coloured on CGB hardware
+	dmgColors [3][4]color.RGBA
+
+	lcdc uint8
+	stat uint8 // Only the interrupt source bits, mode and LYC flag are derived
+	scy  uint8
+	scx  uint8
+	ly   uint8
+	lyc  uint8
+	bgp  uint8
+	obp0 uint8
+	obp1 uint8
+	wy   uint8
+	wx   uint8
+
+	mode     ppuMode
+	dot      int  // Dot within the current scanline
+	statLine bool // STAT interrupts fire on the rising edge of this line
+
+	windowLine      int  // Internal window line counter
+	windowTriggered bool // WY matched LY during this frame
+
+	renderer    lineRenderer
+	lineSprites [maxSpritesPerLine]oamEntry // Sprites selected by the OAM scan of this line
+	spriteCount int
+
+	// bgLine holds the BG/window pixels of the current line, mixed with sprites once complete
+	bgLine [ScreenWidth]bgPixel
+
+	back   *image.RGBA // Frame being drawn
+	front  *image.RGBA // Last completed frame
+	frames uint64
+
+	requestInterrupt func(mask uint8)
+	onHBlank         func() // Called when a visible line enters HBlank, drives HDMA
+}
+
+// bgPixel is a background or window pixel waiting to be mixed with sprites
+type bgPixel struct {
+	color    uint8 // 2-bit colour index
+	palette  uint8 // CGB palette 0–7
+	priority bool  // CGB attribute bit 7: drawn over sprites unless colour 0
+}
+
+// BG map attributes, VRAM bank 1 (CGB)
+const (
+	bgAttrPalette  uint8 = 0x07
+	bgAttrBank     uint8 = 1 << 3
+	bgAttrFlipX    uint8 = 1 << 5
+	bgAttrFlipY    uint8 = 1 << 6
+	bgAttrPriority uint8 = 1 << 7
+)
+
+// NewPPU creates a PPU; cgb enables VRAM bank 1 attributes and the colour palettes
+func NewPPU(requestInterrupt func(mask uint8), mode RenderMode, cgb bool) *PPU {
+	p := &PPU{
+		back:             image.NewRGBA(image.Rect(0, 0, ScreenWidth, ScreenHeight)),
+		front:            image.NewRGBA(image.Rect(0, 0, ScreenWidth, ScreenHeight)),
+		cgb:              cgb,
+		dmgColors:        [3][4]color.RGBA{dmgShades, dmgShades, dmgShades},
+		requestInterrupt: requestInterrupt,
+	}
+	switch mode {
+	case RenderFIFO:
+		p.renderer = &fifoRenderer{p: p}
+	default:
+		p.renderer = &scanlineRenderer{p: p}
+	}
+	p.clearFrame(p.front)
+	p.clearFrame(p.back)
+	return p
+}
+
+// Frame returns the last completed frame, valid until the next VBlank
+func (p *PPU) Frame() *image.RGBA {
+	return p.front
+}
+
+// Frames returns the number of frames completed so far
+func (p *PPU) Frames() uint64 {
+	return p.frames
+}
+
+// Tick advances the PPU by the given number of dots
+func (p *PPU) Tick(cycles int) {
+	if p.lcdc&lcdcEnable == 0 {
+		return
+	}
+	for range cycles {
+		p.tickDot()
+	}
+}
+
+func (p *PPU) tickDot() {
+	p.dot++
+
+	switch p.mode {
+	case modeOAMScan:
+		if p.dot == oamScanDots {
+			p.selectSprites()
+			p.setMode(modeDrawing)
+			p.renderer.startLine()
+		}
+	case modeDrawing:
+		if p.renderer.drawDot() {
+			p.setMode(modeHBlank)
+			if p.onHBlank != nil {
+				p.onHBlank()
+			}
+		}
+	case modeHBlank:
+		if p.dot == dotsPerLine {
+			p.nextLine()
+			if p.ly == ScreenHeight {
+				p.setMode(modeVBlank)
+				p.requestInterrupt(VBlankInterrupt)
+				p.swapFrames()
+			} else {
+				p.setMode(modeOAMScan)
+			}
+		}
+	case modeVBlank:
+		if p.dot == dotsPerLine {
+			p.nextLine()
+			if p.ly == linesPerFrame {
+				p.ly = 0
+				p.windowLine = 0
+				p.windowTriggered = false
+				p.setMode(modeOAMScan)
+			}
+		}
+	}
+
+	p.updateStatLine()
+}
+
+func (p *PPU) nextLine() {
+	p.dot = 0
+	p.ly++
+}
+
+func (p *PPU) setMode(mode ppuMode) {
+	p.mode = mode
+	if mode == modeOAMScan && p.ly == p.wy {
+		p.windowTriggered = true
+	}
+}
+
+// updateStatLine ORs every enabled STAT source and raises the interrupt on a
+// rising edge, so overlapping sources only trigger once ("STAT blocking")
+func (p *PPU) updateStatLine() {
+	line := (p.stat&statLYCSource != 0 && p.ly == p.lyc) ||
+		(p.stat&statHBlankSource != 0 && p.mode == modeHBlank) ||
+		(p.stat&statVBlankSource != 0 && p.mode == modeVBlank) ||
+		(p.stat&statOAMSource != 0 && p.mode == modeOAMScan)
+
+	if line && !p.statLine {
+		p.requestInterrupt(StatInterrupt)
+	}
+	p.statLine = line
+}
+
+func (p *PPU) swapFrames() {
+	p.front, p.back = p.back, p.front
+	p.frames++
+}
+
+func (p *PPU) clearFrame(img *image.RGBA) {
+	for y := range ScreenHeight {
+		for x := range ScreenWidth {
+			img.SetRGBA(x, y, dmgShades[0])
+		}
+	}
+}
+
+// setLCDC handles LCD enable transitions: turning the LCD off resets LY and the mode
+func (p *PPU) setLCDC(value uint8) {
+	wasOn := p.lcdc&lcdcEnable != 0
+	p.lcdc = value
+	isOn := value&lcdcEnable != 0
+
+	switch {
+	case wasOn && !isOn:
+		p.ly = 0
+		p.dot = 0
+		p.mode = modeHBlank
+		p.statLine = false
+		p.clearFrame(p.front)
+	case !wasOn && isOn:
+		p.ly = 0
+		p.dot = 0
+		p.windowLine = 0
+		p.windowTriggered = false
+		p.setMode(modeOAMScan)
+		p.updateStatLine()
+	}
+}
+
+// vramAccessible reports whether the CPU can reach VRAM (blocked in mode 3)
+func (p *PPU) vramAccessible() bool {
+	return p.lcdc&lcdcEnable == 0 || p.mode != modeDrawing
+}
+
+// oamAccessible reports whether the CPU can reach OAM (blocked in modes 2 and 3)
+func (p *PPU) oamAccessible() bool {
+	return p.lcdc&lcdcEnable == 0 || (p.mode != modeOAMScan && p.mode != modeDrawing)
+}
+
+func (p *PPU) ReadVRAM(addr uint16) uint8 {
+	if !p.vramAccessible() {
+		return 0xFF
+	}
+	return p.vram[p.vramBank][addr-0x8000]
+}
+
+func (p *PPU) WriteVRAM(addr uint16, value uint8) {
+	if !p.vramAccessible() {
+		return
+	}
+	p.vram[p.vramBank][addr-0x8000] = value
+}
+
+func (p *PPU) ReadOAM(addr uint16) uint8 {
+	if !p.oamAccessible() {
+		return 0xFF
+	}
+	return p.oam[addr-0xFE00]
+}
+
+func (p *PPU) WriteOAM(addr uint16, value uint8) {
+	if !p.oamAccessible() {
+		return
+	}
+	p.oam[addr-0xFE00] = value
+}
+
+func (p *PPU) ReadRegister(addr uint16) uint8 {
+	switch addr {
+	case lcdcReg:
+		return p.lcdc
+	case statReg:
+		mode := p.mode
+		if p.lcdc&lcdcEnable == 0 {
+			mode = modeHBlank
+		}
+		lycFlag := uint8(0)
+		if p.ly == p.lyc {
+			lycFlag = statLYCEqual
+		}
+		// Bit 7 is unused and always reads as 1
+		return 0x80 | p.stat&statWritable | lycFlag | uint8(mode)
+	case scyReg:
+		return p.scy
+	case scxReg:
+		return p.scx
+	case lyReg:
+		return p.ly
+	case lycReg:
+		return p.lyc
+	case bgpReg:
+		return p.bgp
+	case obp0Reg:
+		return p.obp0
+	case obp1Reg:
+		return p.obp1
+	case wyReg:
+		return p.wy
+	case wxReg:
+		return p.wx
+	}
+	return 0xFF
+}
+
+func (p *PPU) WriteRegister(addr uint16, value uint8) {
+	switch addr {
+	case lcdcReg:
+		p.setLCDC(value)
+	case statReg:
+		p.stat = value & statWritable
+	case scyReg:
+		p.scy = value
+	case scxReg:
+		p.scx = value
+	case lyReg:
+		// Read-only
+	case lycReg:
+		p.lyc = value
+	case bgpReg:
+		p.bgp = value
+	case obp0Reg:
+		p.obp0 = value
+	case obp1Reg:
+		p.obp1 = value
+	case wyReg:
+		p.wy = value
+	case wxReg:
+		p.wx = value
+	}
+	if p.lcdc&lcdcEnable != 0 {
+		p.updateStatLine()
+	}
+}
+
+// tileDataAddr returns the VRAM offset of row (0–7) of a tile, addressed
+// through LCDC bit 4 for BG/window tiles
+func (p *PPU) tileDataAddr(tileIndex uint8, row int, objTile bool) int {
+	if objTile || p.lcdc&lcdcTileData != 0 {
+		return int(tileIndex)*16 + row*2
+	}
+	return 0x1000 + int(int8(tileIndex))*16 + row*2
+}
+
+// tileRow returns the two bitplanes of row (0–7) of a tile in a VRAM bank
+func (p *PPU) tileRow(bank int, tileIndex uint8, row int, objTile bool) (uint8, uint8) {
+	addr := p.tileDataAddr(tileIndex, row, objTile)
+	return p.vram[bank][addr], p.vram[bank][addr+1]
+}
+
+// bgAttributes returns the CGB attributes of a tile map entry, 0 on DMG
+func (p *PPU) bgAttributes(mapAddr int) uint8 {
+	if !p.cgb {
+		return 0
+	}
+	return p.vram[1][mapAddr]
+}
+
+// attrBank returns the VRAM bank selected by bit 3 of BG or sprite attributes
+func attrBank(attrs uint8) int {
+	return int(attrs>>3) & 1
+}
+
+// pixelIndex extracts the 2-bit colour index of bit (7 is the leftmost pixel)
+func pixelIndex(lo, hi uint8, bit int) uint8 {
+	return (hi>>bit)&1<<1 | (lo>>bit)&1
+}
+
+// paletteShade maps a colour index through a DMG palette register
+func paletteShade(palette, index uint8) uint8 {
+	return (palette >> (index * 2)) & 0x03
+}
+
+// windowVisible reports whether the window covers part of the current line
+func (p *PPU) windowVisible() bool {
+	return p.lcdc&lcdcWindowEnable != 0 && p.windowTriggered && p.wx <= 166
+}
+
+// mixPixel resolves a background and a sprite pixel into the output colour
+func (p *PPU) mixPixel(bg bgPixel, obj objPixel) color.RGBA {
+	if p.cgb {
+		return p.mixPixelCGB(bg, obj)
+	}
+
+	if p.lcdc&lcdcBGEnable == 0 {
+		bg.color = 0
+	}
+	if obj.color != 0 && p.lcdc&lcdcOBJEnable != 0 && !(obj.priority && bg.color != 0) {
+		palette := p.obp0
+		if obj.palette != 0 {
+			palette = p.obp1
+		}
+		return p.dmgColors[dmgColorsOBJ0+int(obj.palette)][paletteShade(palette, obj.color)]
+	}
+	return p.dmgColors[dmgColorsBG][paletteShade(p.bgp, bg.color)]
+}
+
+// mixPixelCGB applies the CGB rules: LCDC bit 0 clears every BG priority, else
+// BG colours 1–3 win when either the BG attribute or the sprite asks for it
+func (p *PPU) mixPixelCGB(bg bgPixel, obj objPixel) color.RGBA {
+	if obj.color != 0 && p.lcdc&lcdcOBJEnable != 0 {
+		bgWins := p.lcdc&lcdcBGEnable != 0 && bg.color != 0 && (bg.priority || obj.priority)
+		if !bgWins {
+			return rgb555ToRGBA(p.objPalettes.rgb555(obj.palette, obj.color), p.colorCorrection)
+		}
+	}
+	return rgb555ToRGBA(p.bgPalettes.rgb555(bg.palette, bg.color), p.colorCorrection)
+}
+
+// tileMapBase returns the VRAM offset of the 0x9800 or 0x9C00 tile map
+func tileMapBase(high bool) int {
+	if high {
+		return 0x1C00
+	}
+	return 0x1800
+}
@@ -0,0 +1,225 @@
+package main
+
+const (
+	tileFetchDots   = 6 // Tile index, low and high bitplane reads, 2 dots each
+	spriteFetchDots = 6
+	spriteMaxWait   = 5 // Dots the sprite fetch waits at most for the BG fetcher
+)
+
+// fifoRenderer emulates the DMG pixel pipeline: a background fetcher feeding an
+// 8-pixel FIFO, a sprite FIFO mixed in as pixels are shifted out, SCX fine scroll
+// discarding, window restarts and the stalls caused by sprite fetches. Registers
+// are read as the pixels are fetched and shifted out, so mid-line writes show up.
+type fifoRenderer struct {
+	p *PPU
+
+	bg      [8]bgPixel // Background FIFO, only refilled when empty
+	bgCount int
+	obj     [8]objPixel
+
+	fetchDots int // Dots spent on the current tile fetch
+	fetchX    int // Tile column being fetched
+	tileIndex uint8
+	tileAttrs uint8
+	tileLo    uint8
+	tileHi    uint8
+
+	x       int // Next LCD column
+	discard int // Pixels still to drop for SCX fine scroll
+	stall   int // Dots left of the discarded first fetch of the line
+
+	window     bool // Fetching from the window map
+	windowUsed bool
+
+	sprite      int // Index into lineSprites being fetched, -1 if none
+	spriteDots  int // Dots left until the sprite fetch completes
+	spriteFetch [maxSpritesPerLine]bool
+	spriteTile  int // 1 + the BG/window tile the last sprite started in, 0 if none
+}
+
+func (f *fifoRenderer) startLine() {
+	*f = fifoRenderer{
+		p:       f.p,
+		discard: int(f.p.scx & 7),
+		stall:   tileFetchDots,
+		sprite:  -1,
+	}
+}
+
+func (f *fifoRenderer) drawDot() bool {
+	p := f.p
+
+	// The line ends one dot after the last pixel is shifted out
+	if f.x == ScreenWidth {
+		if f.windowUsed {
+			p.windowLine++
+		}
+		return true
+	}
+
+	if f.stall > 0 {
+		f.stall--
+		return false
+	}
+
+	// Reaching WX restarts the fetcher on the window tile map. With WX < 7 the
+	// window starts left of the screen and its first 7-WX pixels are dropped.
+	if !f.window && f.discard == 0 && p.windowVisible() && f.x >= int(p.wx)-7 {
+		f.window = true
+		f.windowUsed = true
+		f.bgCount = 0
+		f.fetchX = 0
+		f.fetchDots = 0
+		f.discard = max(0, 7-int(p.wx))
+		f.spriteTile = 0
+		return false
+	}
+
+	if f.sprite < 0 && p.lcdc&lcdcOBJEnable != 0 {
+		if f.sprite = f.nextSprite(); f.sprite >= 0 {
+			f.spriteDots = f.spritePenalty(p.lineSprites[f.sprite])
+		}
+	}
+	if f.sprite >= 0 {
+		// The pipeline is paused while the sprite is fetched
+		f.spriteDots--
+		if f.spriteDots == 0 {
+			f.mergeSprite(p.lineSprites[f.sprite])
+			f.spriteFetch[f.sprite] = true
+			f.sprite = -1
+		}
+		return false
+	}
+
+	f.stepFetcher()
+	if f.bgCount == 0 {
+		return false
+	}
+
+	bg := f.bg[len(f.bg)-f.bgCount]
+	f.bgCount--
+	if f.discard > 0 {
+		f.discard--
+		return false
+	}
+
+	obj := f.obj[0]
+	copy(f.obj[:], f.obj[1:])
+	f.obj[len(f.obj)-1] = objPixel{}
+
+	p.back.SetRGBA(f.x, int(p.ly), p.mixPixel(bg, obj))
+	f.x++
+	return false
+}
+
+// nextSprite returns the pending sprite that has reached the current column.
+// Fetch order is priority order, as earlier sprites keep their FIFO pixels.
+func (f *fifoRenderer) nextSprite() int {
+	next := -1
+	for i, s := range f.p.lineSprites[:f.p.spriteCount] {
+		if f.spriteFetch[i] || int(s.x) > f.x+8 {
+			continue
+		}
+		if next < 0 || f.p.spriteBefore(s, f.p.lineSprites[next]) {
+			next = i
+		}
+	}
+	return next
+}
+
+// spritePenalty returns the dots fetching s adds to mode 3: the fetch itself,
+// plus for the first sprite in a BG or window tile the wait for the background
+// fetcher, shorter the further right in the tile the sprite starts. A sprite
+// at X=0 always waits the longest.
+func (f *fifoRenderer) spritePenalty(s oamEntry) int {
+	// Position of the sprite's leftmost pixel relative to the first tile of the line or window
+	pos := int(s.x) - 8 + int(f.p.scx&7)
+	if f.window {
+		pos = int(s.x) - 8 - (int(f.p.wx) - 7)
+	}
+	pos += 16 // Keeps the tile and column arithmetic positive
+	tile := pos/8 + 1
+
+	if tile == f.spriteTile {
+		return spriteFetchDots
+	}
+	f.spriteTile = tile
+	if s.x == 0 {
+		return spriteFetchDots + spriteMaxWait
+	}
+	return spriteFetchDots + max(0, spriteMaxWait-pos%8)
+}
+
+func (f *fifoRenderer) stepFetcher() {
+	p := f.p
+	f.fetchDots++
+
+	switch f.fetchDots {
+	case 2:
+		mapAddr := f.tileMapAddr()
+		f.tileIndex = p.vram[0][mapAddr]
+		f.tileAttrs = p.bgAttributes(mapAddr)
+	case 4:
+		f.tileLo = p.vram[attrBank(f.tileAttrs)][p.tileDataAddr(f.tileIndex, f.tileRowIndex(), false)]
+	case 6:
+		f.tileHi = p.vram[attrBank(f.tileAttrs)][p.tileDataAddr(f.tileIndex, f.tileRowIndex(), false)+1]
+	}
+
+	if f.fetchDots >= tileFetchDots && f.bgCount == 0 {
+		for i := range f.bg {
+			bit := 7 - i
+			if f.tileAttrs&bgAttrFlipX != 0 {
+				bit = i
+			}
+			f.bg[i] = bgPixel{
+				color:    pixelIndex(f.tileLo, f.tileHi, bit),
+				palette:  f.tileAttrs & bgAttrPalette,
+				priority: f.tileAttrs&bgAttrPriority != 0,
+			}
+		}
+		f.bgCount = len(f.bg)
+		f.fetchX++
+		f.fetchDots = 0
+	}
+}
+
+// tileMapAddr returns the VRAM offset of the tile map entry being fetched
+func (f *fifoRenderer) tileMapAddr() int {
+	p := f.p
+	if f.window {
+		base := tileMapBase(p.lcdc&lcdcWindowTileMap != 0)
+		return base + (p.windowLine/8)*32 + (f.fetchX & 31)
+	}
+	base := tileMapBase(p.lcdc&lcdcBGTileMap != 0)
+	mapX := (int(p.scx)/8 + f.fetchX) & 31
+	mapY := (int(p.ly) + int(p.scy)) & 0xFF
+	return base + (mapY/8)*32 + mapX
+}
+
+func (f *fifoRenderer) tileRowIndex() int {
+	row := (int(f.p.ly) + int(f.p.scy)) % 8
+	if f.window {
+		row = f.p.windowLine % 8
+	}
+	if f.tileAttrs&bgAttrFlipY != 0 {
+		row = 7 - row
+	}
+	return row
+}
+
+// mergeSprite mixes a sprite row into the sprite FIFO. Pixels already there
+// belong to sprites fetched earlier, which have priority on DMG, so only
+// transparent ones are replaced; on CGB a lower OAM index takes over.
+func (f *fifoRenderer) mergeSprite(s oamEntry) {
+	pixels := f.p.spriteRow(s)
+	byIndex := f.p.indexPriority()
+
+	// Sprites starting left of the current column lose their first columns
+	skip := max(0, f.x+8-int(s.x))
+	for px := skip; px < len(pixels); px++ {
+		slot := &f.obj[px-skip]
+		if slot.color == 0 || (byIndex && pixels[px].color != 0 && pixels[px].index < slot.index) {
+			*slot = pixels[px]
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import "testing"
+
+// mode3Dots runs the PPU to the first line's mode 3 and returns how many dots it lasts
+func mode3Dots(p *PPU) int {
+	for p.mode != modeDrawing {
+		p.tickDot()
+	}
+	dots := 0
+	for p.mode == modeDrawing {
+		p.tickDot()
+		dots++
+	}
+	return dots
+}
+
+func TestFIFOMode3Length(t *testing.T) {
+	tests := []struct {
+		name    string
+		scx     uint8
+		wx      uint8
+		sprites []uint8 // OAM X of sprites on line 0
+		want    int
+	}{
+		{"minimum", 0, 0xFF, nil, 172},
+		{"SCX 3", 3, 0xFF, nil, 175},
+		{"SCX 7", 7, 0xFF, nil, 179},
+		{"sprite at X=0", 0, 0xFF, []uint8{0}, 183},
+		{"sprite at X=0 ignores SCX", 5, 0xFF, []uint8{0}, 188},
+		{"sprite on tile boundary", 0, 0xFF, []uint8{8}, 183},
+		{"sprite 5 pixels into a tile", 0, 0xFF, []uint8{13}, 178},
+		{"sprite shifted by SCX", 3, 0xFF, []uint8{8}, 183},
+		{"two sprites in one tile", 0, 0xFF, []uint8{8, 8}, 189},
+		{"sprites in two tiles", 0, 0xFF, []uint8{8, 16}, 194},
+		{"rightmost visible sprite", 0, 0xFF, []uint8{167}, 178},
+		{"sprite off the right edge", 0, 0xFF, []uint8{168}, 172},
+		{"window", 0, 87, nil, 178},
+		{"sprite in the window", 0, 87, []uint8{88}, 189},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPPU(RenderFIFO, 0x91|lcdcOBJEnable|lcdcWindowEnable)
+			p.scx = tt.scx
+			p.wx = tt.wx
+			for i, x := range tt.sprites {
+				setSprite(p, i, 16, x, 0, 0)
+			}
+			if got := mode3Dots(p); got != tt.want {
+				t.Errorf("mode 3 lasted %d dots, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBGScrollAndWindow checks the SCX fine scroll and where the window starts,
+// including WX < 7 where its first 7-WX pixels are cut off, on both renderers
+func TestBGScrollAndWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		scx  uint8
+		wx   uint8 // 0xFF keeps the window off screen
+	}{
+		{"no scroll", 0, 0xFF},
+		{"SCX 1", 1, 0xFF},
+		{"SCX 5", 5, 0xFF},
+		{"SCX 13", 13, 0xFF},
+		{"window mid-line", 0, 87},
+		{"window mid-line with SCX", 6, 90},
+		{"window at WX 7", 3, 7},
+		{"window at WX 3", 0, 3},
+		{"window at WX 0", 5, 0},
+		{"window at WX 166", 0, 166},
+	}
+	for _, r := range renderers {
+		for _, tt := range tests {
+			t.Run(r.name+"/"+tt.name, func(t *testing.T) {
+				p := newTestPPU(r.mode, 0x91|lcdcWindowEnable|lcdcWindowTileMap)
+				// BG tiles start with a colour 3 column then colour 1, window tiles with 3 then 2
+				setTile(p, 1, 0xFF, 0x80)
+				setTile(p, 2, 0x80, 0xFF)
+				for i := range 0x400 {
+					p.vram[0][0x1800+i] = 1
+					p.vram[0][0x1C00+i] = 2
+				}
+				p.scx = tt.scx
+				p.wx = tt.wx
+				frame := runFrame(p)
+
+				windowX := int(tt.wx) - 7
+				for _, y := range []int{0, 77, 143} {
+					for x := range ScreenWidth {
+						want := 1
+						if (x+int(tt.scx))%8 == 0 {
+							want = 3
+						}
+						if tt.wx != 0xFF && x >= windowX {
+							want = 2
+							if (x-windowX)%8 == 0 {
+								want = 3
+							}
+						}
+						if got := shadeAt(t, frame, x, y); got != want {
+							t.Fatalf("pixel %d,%d = %d, want %d", x, y, got, want)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestFIFOMidLineSCX checks the FIFO picks up an SCX write in the middle of mode 3
+func TestFIFOMidLineSCX(t *testing.T) {
+	p := newTestPPU(RenderFIFO, 0x91)
+	setTile(p, 1, 0xFF, 0x00)
+	for col := 0; col < 32; col += 2 {
+		p.vram[0][0x1800+col] = 1 // Even tile columns colour 1, odd ones colour 0
+	}
+	for p.mode != modeDrawing {
+		p.tickDot()
+	}
+	for range 60 {
+		p.tickDot()
+	}
+	p.scx = 8 // Swaps even and odd columns for the tiles fetched from now on
+	frame := runFrame(p)
+
+	if got := shadeAt(t, frame, 0, 0); got != 1 {
+		t.Errorf("leftmost pixel = %d, want the unscrolled colour 1", got)
+	}
+	if got := shadeAt(t, frame, 152, 0); got != 1 {
+		t.Errorf("last tile = %d, want the scrolled colour 1 of column 20", got)
+	}
+	if got := shadeAt(t, frame, 0, 1); got != 0 {
+		t.Errorf("next line's first pixel = %d, want the scrolled colour 0", got)
+	}
+}
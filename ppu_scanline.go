@@ -0,0 +1,104 @@
+package main
+
+// scanlineRenderer draws a whole line at the start of mode 3 from the register
+// values at that moment. Mode 3 always lasts the minimum 172 dots.
+type scanlineRenderer struct {
+	p    *PPU
+	dots int
+}
+
+func (r *scanlineRenderer) startLine() {
+	r.dots = 0
+	r.p.renderScanline()
+}
+
+func (r *scanlineRenderer) drawDot() bool {
+	r.dots++
+	return r.dots == drawingDots
+}
+
+// renderScanline draws the background, window and sprites of the current line
+func (p *PPU) renderScanline() {
+	y := int(p.ly)
+	if y >= ScreenHeight {
+		return
+	}
+
+	p.renderBackground(y)
+	objLine := p.renderSprites()
+	for x := range ScreenWidth {
+		p.back.SetRGBA(x, y, p.mixPixel(p.bgLine[x], objLine[x]))
+	}
+}
+
+// renderBackground fills bgLine with the BG and window pixels of the line
+func (p *PPU) renderBackground(y int) {
+	if !p.cgb && p.lcdc&lcdcBGEnable == 0 {
+		// BG and window are blank, sprites are still drawn
+		clear(p.bgLine[:])
+		return
+	}
+
+	windowX := int(p.wx) - 7
+	drawWindow := p.windowVisible()
+
+	for x := range ScreenWidth {
+		var mapBase, mapX, mapY int
+		if drawWindow && x >= windowX {
+			mapBase = tileMapBase(p.lcdc&lcdcWindowTileMap != 0)
+			mapX = x - windowX
+			mapY = p.windowLine
+		} else {
+			mapBase = tileMapBase(p.lcdc&lcdcBGTileMap != 0)
+			mapX = (x + int(p.scx)) & 0xFF
+			mapY = (y + int(p.scy)) & 0xFF
+		}
+
+		mapAddr := mapBase + (mapY/8)*32 + mapX/8
+		attrs := p.bgAttributes(mapAddr)
+		row, bit := mapY%8, 7-mapX%8
+		if attrs&bgAttrFlipY != 0 {
+			row = 7 - row
+		}
+		if attrs&bgAttrFlipX != 0 {
+			bit = mapX % 8
+		}
+		lo, hi := p.tileRow(attrBank(attrs), p.vram[0][mapAddr], row, false)
+		p.bgLine[x] = bgPixel{
+			color:    pixelIndex(lo, hi, bit),
+			palette:  attrs & bgAttrPalette,
+			priority: attrs&bgAttrPriority != 0,
+		}
+	}
+
+	if drawWindow && windowX < ScreenWidth {
+		p.windowLine++
+	}
+}
+
+// renderSprites resolves the sprites selected by the OAM scan into one pixel
+// per column. The sprite with priority owns the column even when its
+// BG-over-OBJ bit then hides it behind the background.
+func (p *PPU) renderSprites() [ScreenWidth]objPixel {
+	var line [ScreenWidth]objPixel
+	if p.lcdc&lcdcOBJEnable == 0 {
+		return line
+	}
+
+	var owner [ScreenWidth]oamEntry
+	for _, s := range p.lineSprites[:p.spriteCount] {
+		left := int(s.x) - 8
+		for px, pixel := range p.spriteRow(s) {
+			x := left + px
+			if x < 0 || x >= ScreenWidth || pixel.color == 0 {
+				continue
+			}
+			if line[x].color != 0 && !p.spriteBefore(s, owner[x]) {
+				continue
+			}
+			line[x] = pixel
+			owner[x] = s
+		}
+	}
+	return line
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+// newTestPPU returns a DMG PPU with the given LCDC (LCD on) and identity palettes,
+// so every output shade equals the colour index that produced it
+func newTestPPU(mode RenderMode, lcdc uint8) *PPU {
+	p := NewPPU(func(uint8) {}, mode, false)
+	p.bgp, p.obp0, p.obp1 = 0xE4, 0xE4, 0xE4
+	p.setLCDC(lcdc | lcdcEnable)
+	return p
+}
+
+// setTile fills every row of a tile in the 0x8000 area with the same bitplanes
+func setTile(p *PPU, index uint8, lo, hi uint8) {
+	for row := range 8 {
+		p.vram[0][int(index)*16+row*2] = lo
+		p.vram[0][int(index)*16+row*2+1] = hi
+	}
+}
+
+// setSprite writes OAM entry i
+func setSprite(p *PPU, i int, y, x, tile, attrs uint8) {
+	copy(p.oam[i*4:], []uint8{y, x, tile, attrs})
+}
+
+// runFrame ticks until the next frame is complete and returns it
+func runFrame(p *PPU) *image.RGBA {
+	frames := p.Frames()
+	for p.Frames() == frames {
+		p.tickDot()
+	}
+	return p.Frame()
+}
+
+// shadeAt returns the DMG shade drawn at x, y
+func shadeAt(t *testing.T, img *image.RGBA, x, y int) int {
+	t.Helper()
+	c := img.RGBAAt(x, y)
+	for shade, s := range dmgShades {
+		if s == c {
+			return shade
+		}
+	}
+	t.Fatalf("pixel %d,%d is not a DMG shade: %v", x, y, c)
+	return 0
+}
+
+// renderers lists the PPU implementations every rendering test runs against
+var renderers = []struct {
+	name string
+	mode RenderMode
+}{
+	{"scanline", RenderScanline},
+	{"fifo", RenderFIFO},
+}
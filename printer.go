@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Printer packets: 0x88 0x33, command, compression, length (LE), data,
+// checksum (LE) of everything after the magic, then two bytes during which the
+// printer answers 0x81 and its status
+const (
+	printerMagic1 = 0x88
+	printerMagic2 = 0x33
+	printerAlive  = 0x81
+)
+
+const (
+	printerCmdInit   = 0x01
+	printerCmdPrint  = 0x02
+	printerCmdData   = 0x04
+	printerCmdStatus = 0x0F
+)
+
+// Status bits
+const (
+	printerChecksumError uint8 = 1 << 0
+	printerBusy          uint8 = 1 << 1
+	printerImageFull     uint8 = 1 << 2
+	printerUnprocessed   uint8 = 1 << 3
+)
+
+const (
+	printerWidth       = 160
+	printerBandBytes   = 20 * 16   // One row of 20 tiles
+	printerBufferBytes = 9 * 640   // 9 data packets of two tile rows each
+	printerBusyPolls   = 4         // Status packets answered busy after a print
+	printerMaxPacket   = 4 + 0x280 // Header plus the largest data payload
+)
+
+type printerState uint8
+
+const (
+	printerWaitMagic1 printerState = iota
+	printerWaitMagic2
+	printerHeader
+	printerData
+	printerChecksum
+	printerAck
+	printerStatus
+)
+
+// dmgPrinterShades are the printed shades of colour 0–3 after the print palette
+var dmgPrinterShades = [4]uint8{0xFF, 0xAA, 0x55, 0x00}
+
+// Printer is a Game Boy Printer on the link port. Printed strips are stacked
+// into one sheet until a print with a bottom margin feeds the paper, then the
+// sheet is written as a PNG to the output directory.
+type Printer struct {
+	dir string
+
+	state    printerState
+	header   [4]byte // Command, compression, length
+	headerAt int
+	packet   []byte // Payload as received, possibly compressed
+	length   int
+	sum      uint16 // Checksum computed over header and payload
+	checkLo  uint8
+	checkAt  int
+
+	status    uint8
+	busyPolls int
+	buffer    []byte // Decompressed tile data since the last init
+	sheet     []uint8
+	sheets    int
+}
+
+func NewPrinter(dir string) *Printer {
+	return &Printer{dir: dir}
+}
+
+func (p *Printer) Exchange(out uint8) uint8 {
+	switch p.state {
+	case printerWaitMagic1:
+		if out == printerMagic1 {
+			p.state = printerWaitMagic2
+		}
+	case printerWaitMagic2:
+		if out == printerMagic2 {
+			p.state = printerHeader
+			p.headerAt = 0
+			p.sum = 0
+			p.packet = p.packet[:0]
+		} else {
+			p.state = printerWaitMagic1
+		}
+	case printerHeader:
+		p.header[p.headerAt] = out
+		p.headerAt++
+		p.sum += uint16(out)
+		if p.headerAt == len(p.header) {
+			p.length = int(p.header[2]) | int(p.header[3])<<8
+			p.state = printerData
+			if p.length == 0 {
+				p.state = printerChecksum
+				p.checkAt = 0
+			}
+		}
+	case printerData:
+		if len(p.packet) < printerMaxPacket {
+			p.packet = append(p.packet, out)
+		}
+		p.sum += uint16(out)
+		if p.length--; p.length == 0 {
+			p.state = printerChecksum
+			p.checkAt = 0
+		}
+	case printerChecksum:
+		if p.checkAt == 0 {
+			p.checkLo = out
+			p.checkAt++
+		} else {
+			p.state = printerAck
+			if uint16(p.checkLo)|uint16(out)<<8 != p.sum {
+				p.status |= printerChecksumError
+			} else {
+				p.status &^= printerChecksumError
+				p.execute()
+			}
+		}
+	case printerAck:
+		p.state = printerStatus
+		return printerAlive
+	case printerStatus:
+		p.state = printerWaitMagic1
+		return p.currentStatus()
+	}
+	return 0x00
+}
+
+// currentStatus reports busy for a few polls after a print, as the paper feeds
+func (p *Printer) currentStatus() uint8 {
+	status := p.status
+	if p.busyPolls > 0 {
+		status |= printerBusy
+		if p.header[0] == printerCmdStatus {
+			p.busyPolls--
+		}
+	}
+	return status
+}
+
+func (p *Printer) execute() {
+	switch p.header[0] {
+	case printerCmdInit:
+		p.buffer = p.buffer[:0]
+		p.status = 0
+		p.busyPolls = 0
+	case printerCmdData:
+		data := p.packet
+		if p.header[1] != 0 {
+			data = decompressPrinterData(data)
+		}
+		p.buffer = append(p.buffer, data...)
+		if len(p.buffer) > printerBufferBytes {
+			p.buffer = p.buffer[:printerBufferBytes]
+		}
+		p.status |= printerUnprocessed
+		if len(p.buffer) == printerBufferBytes {
+			p.status |= printerImageFull
+		}
+	case printerCmdPrint:
+		if len(p.packet) < 4 {
+			return
+		}
+		margins, palette := p.packet[1], p.packet[2]
+		if palette == 0 {
+			// Some games leave the palette unset and get the default mapping
+			palette = 0xE4
+		}
+		p.printBuffer(palette)
+		if margins&0x0F != 0 {
+			p.Flush()
+		}
+		p.buffer = p.buffer[:0]
+		p.status &^= printerUnprocessed | printerImageFull
+		p.busyPolls = printerBusyPolls
+	case printerCmdStatus:
+	}
+}
+
+// decompressPrinterData expands the RLE used by data packets: a control byte
+// with bit 7 set repeats the next byte (n&0x7F)+2 times, otherwise n+1 literal
+// bytes follow
+func decompressPrinterData(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); {
+		ctrl := data[i]
+		i++
+		if ctrl&0x80 != 0 {
+			if i >= len(data) {
+				break
+			}
+			for range int(ctrl&0x7F) + 2 {
+				out = append(out, data[i])
+			}
+			i++
+		} else {
+			n := min(int(ctrl)+1, len(data)-i)
+			out = append(out, data[i:i+n]...)
+			i += n
+		}
+	}
+	return out
+}
+
+// printBuffer decodes the tile rows of the buffer and appends them to the sheet
+func (p *Printer) printBuffer(palette uint8) {
+	bands := len(p.buffer) / printerBandBytes
+	for band := range bands {
+		tiles := p.buffer[band*printerBandBytes : (band+1)*printerBandBytes]
+		for row := range 8 {
+			for x := range printerWidth {
+				tile := tiles[(x/8)*16:]
+				index := pixelIndex(tile[row*2], tile[row*2+1], 7-x%8)
+				p.sheet = append(p.sheet, dmgPrinterShades[paletteShade(palette, index)])
+			}
+		}
+	}
+}
+
+// Flush writes the sheet printed so far, if any, as a PNG file
+func (p *Printer) Flush() {
+	if len(p.sheet) == 0 {
+		return
+	}
+	img := image.NewGray(image.Rect(0, 0, printerWidth, len(p.sheet)/printerWidth))
+	for i, shade := range p.sheet {
+		img.SetGray(i%printerWidth, i/printerWidth, color.Gray{Y: shade})
+	}
+	p.sheet = p.sheet[:0]
+	p.sheets++
+
+	name := fmt.Sprintf("print-%s-%03d.png", time.Now().Format("20060102-150405"), p.sheets)
+	if err := writePNG(filepath.Join(p.dir, name), img); err != nil {
+		fmt.Printf("Warning: printer: %v\n", err)
+	}
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sendPacket feeds a whole packet through the link port, returning the two
+// bytes the printer answers at the end. A wrong checksum is sent when corrupt is set.
+func sendPacket(dev SerialDevice, cmd, compression uint8, data []byte, corrupt bool) (alive, status uint8) {
+	body := []byte{cmd, compression, uint8(len(data)), uint8(len(data) >> 8)}
+	body = append(body, data...)
+	var sum uint16
+	for _, b := range body {
+		sum += uint16(b)
+	}
+	if corrupt {
+		sum++
+	}
+	for _, b := range append([]byte{printerMagic1, printerMagic2}, body...) {
+		dev.Exchange(b)
+	}
+	dev.Exchange(uint8(sum))
+	dev.Exchange(uint8(sum >> 8))
+	return dev.Exchange(0), dev.Exchange(0)
+}
+
+// bandData returns one 20-tile band where every pixel has colour index c
+func bandData(c uint8) []byte {
+	lo, hi := uint8(0), uint8(0)
+	if c&1 != 0 {
+		lo = 0xFF
+	}
+	if c&2 != 0 {
+		hi = 0xFF
+	}
+	band := make([]byte, printerBandBytes)
+	for i := 0; i < len(band); i += 2 {
+		band[i], band[i+1] = lo, hi
+	}
+	return band
+}
+
+func TestPrinterPackets(t *testing.T) {
+	dir := t.TempDir()
+	printer := NewPrinter(dir)
+	var dev SerialDevice = printer
+
+	// Noise before the magic bytes is ignored
+	for _, b := range []uint8{0x00, 0x33, 0x88, 0x00} {
+		if got := dev.Exchange(b); got != 0 {
+			t.Fatalf("idle printer answered %02X", got)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		cmd         uint8
+		compression uint8
+		data        []byte
+		corrupt     bool
+		wantStatus  uint8
+	}{
+		{"init", printerCmdInit, 0, nil, false, 0},
+		{"status", printerCmdStatus, 0, nil, false, 0},
+		{"data", printerCmdData, 0, append(bandData(1), bandData(3)...), false, printerUnprocessed},
+		{"bad checksum", printerCmdData, 0, bandData(2), true, printerUnprocessed | printerChecksumError},
+		// 0x80|126 repeats the next byte 128 times: two and a half RLE runs make a band
+		{"compressed data", printerCmdData, 1, []byte{0xFE, 0x00, 0xFE, 0x00, 0xBE, 0x00}, false, printerUnprocessed},
+		{"empty data ends the transfer", printerCmdData, 0, nil, false, printerUnprocessed},
+		// No top margin, 3 lines of bottom margin, identity palette
+		{"print", printerCmdPrint, 0, []byte{0x01, 0x03, 0xE4, 0x40}, false, printerBusy},
+	}
+	for _, tt := range tests {
+		alive, status := sendPacket(dev, tt.cmd, tt.compression, tt.data, tt.corrupt)
+		if alive != printerAlive || status != tt.wantStatus {
+			t.Fatalf("%s: answered %02X %02X, want %02X %02X", tt.name, alive, status, printerAlive, tt.wantStatus)
+		}
+	}
+
+	// The printer stays busy for a few status polls while the paper feeds
+	for i := range printerBusyPolls {
+		if _, status := sendPacket(dev, printerCmdStatus, 0, nil, false); status != printerBusy {
+			t.Fatalf("status poll %d: %02X, want busy", i, status)
+		}
+	}
+	if _, status := sendPacket(dev, printerCmdStatus, 0, nil, false); status != 0 {
+		t.Errorf("status after feeding: %02X, want 00", status)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("printed %d files (%v), want one sheet", len(entries), err)
+	}
+	f, err := os.Open(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bands of colour 1, 3 and the decompressed 0; the corrupt packet is dropped
+	if b := img.Bounds(); b.Dx() != printerWidth || b.Dy() != 24 {
+		t.Fatalf("sheet is %dx%d, want %dx24", b.Dx(), b.Dy(), printerWidth)
+	}
+	for _, tt := range []struct{ y, want int }{{0, 0xAA}, {7, 0xAA}, {8, 0x00}, {15, 0x00}, {16, 0xFF}, {23, 0xFF}} {
+		for _, x := range []int{0, 80, 159} {
+			if r, _, _, _ := img.At(x, tt.y).RGBA(); int(r>>8) != tt.want {
+				t.Fatalf("pixel %d,%d = %02X, want %02X", x, tt.y, r>>8, tt.want)
+			}
+		}
+	}
+}
+
+func TestPrinterSheetWithoutFeed(t *testing.T) {
+	dir := t.TempDir()
+	printer := NewPrinter(dir)
+	sendPacket(printer, printerCmdInit, 0, nil, false)
+	sendPacket(printer, printerCmdData, 0, bandData(2), false)
+	// No bottom margin: the strip stays on the sheet until a flush
+	sendPacket(printer, printerCmdPrint, 0, []byte{0x01, 0x00, 0x00, 0x40}, false)
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatal("print without a bottom margin wrote the sheet")
+	}
+	printer.Flush()
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("flush wrote %d files, want 1", len(entries))
+	}
+	f, err := os.Open(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Palette 0 falls back to the default mapping, colour 2 prints dark grey
+	if r, _, _, _ := img.At(10, 3).RGBA(); r>>8 != 0x55 {
+		t.Errorf("pixel shade %02X, want 55", r>>8)
+	}
+}
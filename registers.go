@@ -1,5 +1,7 @@
 package main
 
+import "fmt"
+
 const (
 	ZeroFlag      uint8 = 1 << 7 // Z - bit 7
 	SubtractFlag  uint8 = 1 << 6 // N - bit 6
@@ -196,3 +198,70 @@ func (r *Registers) inc8Flags(orig, res byte) {
 	r.setFlag(SubtractFlag, false)
 	r.setFlag(HalfCarryFlag, (orig&0x0F) == 0x0F)
 }
+
+func (r *Registers) dec8Flags(orig, res byte) {
+	r.setFlag(ZeroFlag, res == 0)
+	r.setFlag(SubtractFlag, true)
+	r.setFlag(HalfCarryFlag, (orig&0x0F) == 0x00)
+}
+
+// flagsString renders the flag register for instruction traces
+func (r *Registers) flagsString() string {
+	return fmt.Sprintf(
+		"Z=%t N=%t H=%t C=%t",
+		r.getFlag(ZeroFlag),
+		r.getFlag(SubtractFlag),
+		r.getFlag(HalfCarryFlag),
+		r.getFlag(CarryFlag),
+	)
+}
+
+func (r *Registers) getRegisterGetter16(name string) func() uint16 {
+	switch name {
+	case "AF":
+		return r.getAF
+	case "BC":
+		return r.getBC
+	case "DE":
+		return r.getDE
+	case "HL":
+		return r.getHL
+	case "SP":
+		return r.getSP
+	default:
+		return nil
+	}
+}
+
+func (r *Registers) getRegisterSetter16(name string) func(uint16) {
+	switch name {
+	case "AF":
+		return r.setAF
+	case "BC":
+		return r.setBC
+	case "DE":
+		return r.setDE
+	case "HL":
+		return r.setHL
+	case "SP":
+		return r.setSP
+	default:
+		return nil
+	}
+}
+
+// condition reports whether the branch condition (NZ, Z, NC, C) holds
+func (r *Registers) condition(name string) bool {
+	switch name {
+	case "NZ":
+		return !r.getFlag(ZeroFlag)
+	case "Z":
+		return r.getFlag(ZeroFlag)
+	case "NC":
+		return !r.getFlag(CarryFlag)
+	case "C":
+		return r.getFlag(CarryFlag)
+	default:
+		panic(fmt.Sprintf("condition: Unexpected condition %q", name))
+	}
+}
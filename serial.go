@@ -0,0 +1,138 @@
+package main
+
+import "io"
+
+const (
+	serialDataReg    = 0xFF01 // SB
+	serialControlReg = 0xFF02 // SC
+)
+
+const (
+	serialStart         uint8 = 1 << 7
+	serialInternalClock uint8 = 1 << 0
+	serialFastClock     uint8 = 1 << 1 // CGB only
+	serialBitCycles           = 512    // 8192 Hz internal clock
+	serialFastBitCycles       = 16     // 262144 Hz with the CGB fast clock
+)
+
+// SerialDevice is whatever is plugged into the link port
+type SerialDevice interface {
+	// Exchange is called once a byte has been shifted out and returns the
+	// byte shifted in from the other end
+	Exchange(out uint8) uint8
+}
+
+// SerialWriter is a SerialDevice that records every transmitted byte, e.g. to
+// a buffer or stdout, and answers with 0xFF like a disconnected port
+type SerialWriter struct {
+	w io.Writer
+}
+
+func NewSerialWriter(w io.Writer) *SerialWriter {
+	return &SerialWriter{w: w}
+}
+
+func (s *SerialWriter) Exchange(out uint8) uint8 {
+	s.w.Write([]byte{out})
+	return 0xFF
+}
+
+// Serial is the link port: SB is shifted out MSB first, one bit per clock
+type Serial struct {
+	sb     uint8
+	sc     uint8
+	bits   int // Bits shifted in the current transfer
+	cycles int // T-cycles towards the next bit
+
+	device SerialDevice
+	cgb    bool
+
+	link    *Link
+	shifted bool // All 8 bits clocked out, waiting for the link to swap bytes
+
+	requestInterrupt func(mask uint8)
+}
+
+func NewSerial(device SerialDevice, requestInterrupt func(mask uint8)) *Serial {
+	return &Serial{device: device, requestInterrupt: requestInterrupt}
+}
+
+// transferring reports whether a transfer was started and not completed
+func (s *Serial) transferring() bool {
+	return s.sc&serialStart != 0
+}
+
+// Tick advances a transfer clocked by this Game Boy. Transfers using the
+// external clock wait for the other end.
+func (s *Serial) Tick(cycles int) {
+	if !s.transferring() || s.sc&serialInternalClock == 0 || s.shifted {
+		return
+	}
+	bitCycles := serialBitCycles
+	if s.sc&serialFastClock != 0 {
+		bitCycles = serialFastBitCycles
+	}
+	s.cycles += cycles
+	for s.cycles >= bitCycles && s.transferring() {
+		s.cycles -= bitCycles
+		s.bits++
+		if s.bits < 8 {
+			continue
+		}
+		if s.link != nil && s.link.Connected() {
+			// The byte is swapped at the next link sync
+			s.shifted = true
+			return
+		}
+		s.complete()
+	}
+}
+
+// complete swaps the byte with the device and raises the serial interrupt
+func (s *Serial) complete() {
+	in := uint8(0xFF)
+	if s.device != nil {
+		in = s.device.Exchange(s.sb)
+	}
+	s.finish(in)
+}
+
+// finish ends the transfer with the byte shifted in
+func (s *Serial) finish(in uint8) {
+	s.sb = in
+	s.sc &^= serialStart
+	s.bits = 0
+	s.cycles = 0
+	s.shifted = false
+	s.requestInterrupt(SerialInterrupt)
+}
+
+func (s *Serial) ReadRegister(addr uint16) uint8 {
+	switch addr {
+	case serialDataReg:
+		return s.sb
+	case serialControlReg:
+		// Unused bits always read as 1
+		if s.cgb {
+			return s.sc | 0x7C
+		}
+		return s.sc | 0x7E
+	}
+	return 0xFF
+}
+
+func (s *Serial) WriteRegister(addr uint16, value uint8) {
+	switch addr {
+	case serialDataReg:
+		s.sb = value
+	case serialControlReg:
+		mask := serialStart | serialInternalClock
+		if s.cgb {
+			mask |= serialFastClock
+		}
+		s.sc = value & mask
+		s.bits = 0
+		s.cycles = 0
+		s.shifted = false
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+const (
+	oamEntries        = 40
+	maxSpritesPerLine = 10
+)
+
+// Sprite attribute flags
+const (
+	objCGBPalette uint8 = 0x07   // CGB palette 0–7
+	objBank       uint8 = 1 << 3 // CGB VRAM bank
+	objPalette    uint8 = 1 << 4 // 0: OBP0, 1: OBP1 (DMG)
+	objFlipX      uint8 = 1 << 5
+	objFlipY      uint8 = 1 << 6
+	objPriority   uint8 = 1 << 7 // BG colours 1–3 are drawn over the object
+)
+
+// oamEntry is one of the 40 sprites of OAM, 4 bytes each
+type oamEntry struct {
+	y     uint8 // Screen Y + 16
+	x     uint8 // Screen X + 8
+	tile  uint8
+	attrs uint8
+	index int // Position in OAM, breaks ties between sprites at the same X
+}
+
+// objPixel is a sprite pixel waiting to be mixed with the background
+type objPixel struct {
+	color    uint8 // 2-bit colour index, 0 is transparent
+	palette  uint8 // 0: OBP0, 1: OBP1 or the CGB palette, looked up when the pixel is drawn
+	priority bool  // BG colours 1–3 are drawn over this pixel
+	index    uint8 // OAM index of the sprite, orders overlapping sprites on CGB
+}
+
+func (p *PPU) oamEntry(i int) oamEntry {
+	entry := p.oam[i*4 : i*4+4]
+	return oamEntry{y: entry[0], x: entry[1], tile: entry[2], attrs: entry[3], index: i}
+}
+
+func (p *PPU) spriteHeight() int {
+	if p.lcdc&lcdcOBJSize != 0 {
+		return 16
+	}
+	return 8
+}
+
+// selectSprites performs the OAM scan: the first ten sprites in OAM order
+// overlapping LY are kept, whether or not they are visible horizontally
+func (p *PPU) selectSprites() {
+	height := p.spriteHeight()
+	p.spriteCount = 0
+	for i := 0; i < oamEntries && p.spriteCount < maxSpritesPerLine; i++ {
+		s := p.oamEntry(i)
+		top := int(s.y) - 16
+		if int(p.ly) < top || int(p.ly) >= top+height {
+			continue
+		}
+		p.lineSprites[p.spriteCount] = s
+		p.spriteCount++
+	}
+}
+
+// spriteRow decodes the row of a sprite on the current line, leftmost pixel first
+func (p *PPU) spriteRow(s oamEntry) [8]objPixel {
+	height := p.spriteHeight()
+	row := int(p.ly) - (int(s.y) - 16)
+	if s.attrs&objFlipY != 0 {
+		row = height - 1 - row
+	}
+	tile := s.tile
+	if height == 16 {
+		// Bit 0 of the tile index is ignored, the row selects the top or bottom tile
+		tile &= 0xFE
+	}
+	bank := 0
+	palette := uint8(0)
+	if p.cgb {
+		bank = attrBank(s.attrs)
+		palette = s.attrs & objCGBPalette
+	} else if s.attrs&objPalette != 0 {
+		palette = 1
+	}
+	lo, hi := p.tileRow(bank, tile, row, true)
+
+	var pixels [8]objPixel
+	for px := range pixels {
+		bit := 7 - px
+		if s.attrs&objFlipX != 0 {
+			bit = px
+		}
+		pixels[px] = objPixel{
+			color:    pixelIndex(lo, hi, bit),
+			palette:  palette,
+			priority: s.attrs&objPriority != 0,
+			index:    uint8(s.index),
+		}
+	}
+	return pixels
+}
+
+// indexPriority reports whether overlapping sprites are ordered by OAM index
+// alone, as in CGB mode unless OPRI asks for the DMG rule
+func (p *PPU) indexPriority() bool {
+	return p.cgb && p.opri&0x01 == 0
+}
+
+// spriteBefore reports whether a wins over b where both are opaque: on DMG
+// the smaller X wins, then the lower OAM index
+func (p *PPU) spriteBefore(a, b oamEntry) bool {
+	if a.x != b.x && !p.indexPriority() {
+		return a.x < b.x
+	}
+	return a.index < b.index
+}
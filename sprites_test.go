@@ -0,0 +1,239 @@
+package main
+
+import (
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden frames in testdata")
+
+// Sprite test tiles
+const (
+	tileClear  = 0x00
+	tileSolid1 = 0x01
+	tileSolid2 = 0x02
+	tileSolid3 = 0x03
+	tileArrow  = 0x04 // Asymmetric on both axes, see setArrowTile
+	tileHalf   = 0x05 // Left half transparent, right half colour 1
+	tileTop    = 0x06 // 8×16 pair: colour 2 on top
+	tileBottom = 0x07 // and colour 3 below
+)
+
+// setArrowTile draws colour 1 on the left half of the top row, colour 3 in the
+// left column of the middle rows and colour 2 in the right column of the bottom row
+func setArrowTile(p *PPU) {
+	rows := [8][2]uint8{{0xF0, 0x00}}
+	for r := 1; r < 7; r++ {
+		rows[r] = [2]uint8{0x80, 0x80}
+	}
+	rows[7] = [2]uint8{0x00, 0x01}
+	for r, planes := range rows {
+		p.vram[0][int(tileArrow)*16+r*2] = planes[0]
+		p.vram[0][int(tileArrow)*16+r*2+1] = planes[1]
+	}
+}
+
+// newSpriteScene returns a PPU with the sprite test tiles, a blank BG and sprites enabled
+func newSpriteScene(mode RenderMode, lcdc uint8) *PPU {
+	p := newTestPPU(mode, 0x91|lcdcOBJEnable|lcdc)
+	setTile(p, tileSolid1, 0xFF, 0x00)
+	setTile(p, tileSolid2, 0x00, 0xFF)
+	setTile(p, tileSolid3, 0xFF, 0xFF)
+	setTile(p, tileHalf, 0x0F, 0x00)
+	setTile(p, tileTop, 0x00, 0xFF)
+	setTile(p, tileBottom, 0xFF, 0xFF)
+	setArrowTile(p)
+	return p
+}
+
+// checkGolden compares a frame with testdata/<name>.png, rewriting it with -update
+func checkGolden(t *testing.T, name string, frame *image.RGBA) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".png")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writePNG(path, frame); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("golden frame: %v (run go test -update to create it)", err)
+	}
+	defer f.Close()
+	golden, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("golden frame %s: %v", path, err)
+	}
+	for y := range ScreenHeight {
+		for x := range ScreenWidth {
+			gr, gg, gb, _ := golden.At(x, y).RGBA()
+			fr, fg, fb, _ := frame.At(x, y).RGBA()
+			if gr != fr || gg != fg || gb != fb {
+				t.Fatalf("frame differs from %s first at %d,%d", path, x, y)
+			}
+		}
+	}
+}
+
+// pixelCheck is a hand-computed expectation backing up a golden frame
+type pixelCheck struct {
+	x, y  int
+	shade int
+	what  string
+}
+
+func TestSpriteGoldenFrames(t *testing.T) {
+	scenes := []struct {
+		name   string
+		lcdc   uint8
+		setup  func(p *PPU)
+		checks []pixelCheck
+	}{
+		{
+			name: "sprite_limit",
+			setup: func(p *PPU) {
+				// OAM 0 is off screen on the left but still takes a slot on its lines
+				setSprite(p, 0, 16+20, 0, tileSolid3, 0)
+				for i := 1; i <= 11; i++ {
+					setSprite(p, i, 16+20, uint8(8+12*i), tileSolid2, 0)
+				}
+				// Three lines lower only the off-screen sprite is gone from the line
+				for i := 12; i <= 22; i++ {
+					setSprite(p, i, 16+60, uint8(8+12*(i-11)), tileSolid1, 0)
+				}
+			},
+			checks: []pixelCheck{
+				{12, 20, 2, "first of the ten"},
+				{12 * 9, 24, 2, "tenth slot"},
+				{12 * 10, 24, 0, "11th sprite dropped"},
+				{12 * 11, 27, 0, "12th sprite dropped"},
+				{12 * 10, 64, 1, "tenth visible sprite on a line without the hidden one"},
+				{12 * 11, 64, 0, "11th visible sprite dropped"},
+			},
+		},
+		{
+			name: "dmg_priority",
+			setup: func(p *PPU) {
+				p.obp1 = 0x1B // Reversed shades
+				// Smaller X wins even with a higher OAM index
+				setSprite(p, 0, 16+10, 40, tileSolid1, 0)
+				setSprite(p, 1, 16+10, 36, tileSolid2, 0)
+				// Same X: the lower OAM index wins
+				setSprite(p, 2, 16+30, 80, tileSolid1, 0)
+				setSprite(p, 3, 16+30, 80, tileSolid3, 0)
+				// The winner's transparent pixels show the sprite behind
+				setSprite(p, 4, 16+50, 60, tileHalf, 0)
+				setSprite(p, 5, 16+50, 60, tileSolid3, 0)
+				// OBP1
+				setSprite(p, 6, 16+70, 100, tileSolid1, objPalette)
+			},
+			checks: []pixelCheck{
+				{30, 10, 2, "smaller X over the overlap"},
+				{29, 17, 2, "smaller X outside the overlap"},
+				{37, 17, 1, "larger X outside the overlap"},
+				{72, 30, 1, "same X, lower OAM index"},
+				{53, 50, 3, "behind the transparent left half"},
+				{56, 50, 1, "winner's opaque right half"},
+				{59, 57, 1, "winner's opaque right half, last row"},
+				{92, 70, 2, "colour 1 through reversed OBP1"},
+			},
+		},
+		{
+			name: "flips",
+			setup: func(p *PPU) {
+				setSprite(p, 0, 16+10, 8+10, tileArrow, 0)
+				setSprite(p, 1, 16+10, 8+30, tileArrow, objFlipX)
+				setSprite(p, 2, 16+10, 8+50, tileArrow, objFlipY)
+				setSprite(p, 3, 16+10, 8+70, tileArrow, objFlipX|objFlipY)
+			},
+			checks: []pixelCheck{
+				{10, 10, 1, "unflipped top-left"},
+				{17, 17, 2, "unflipped bottom-right"},
+				{37, 10, 1, "X flip top-right"},
+				{30, 17, 2, "X flip bottom-left"},
+				{50, 17, 1, "Y flip bottom-left"},
+				{57, 10, 2, "Y flip top-right"},
+				{77, 17, 1, "XY flip bottom-right"},
+				{70, 10, 2, "XY flip top-left"},
+				{77, 13, 3, "XY flip middle rows on the right"},
+			},
+		},
+		{
+			name: "tall_sprites",
+			lcdc: lcdcOBJSize,
+			setup: func(p *PPU) {
+				// Bit 0 of the tile index is ignored in 8×16 mode
+				setSprite(p, 0, 16+20, 8+20, tileBottom, 0)
+				setSprite(p, 1, 16+20, 8+40, tileTop, objFlipY)
+				setSprite(p, 2, 16+20, 8+60, tileArrow, objFlipX)
+			},
+			checks: []pixelCheck{
+				{20, 20, 2, "top tile"},
+				{20, 35, 3, "bottom tile"},
+				{20, 36, 0, "below the sprite"},
+				{40, 20, 3, "Y flip swaps the tiles"},
+				{40, 35, 2, "Y flip bottom"},
+				{67, 21, 3, "X flip of the top tile"},
+				{67, 28, 0, "bottom tile of tileArrow&0xFE+1 is blank"},
+			},
+		},
+		{
+			name: "bg_priority",
+			setup: func(p *PPU) {
+				p.bgp = 0xE7 // Colour 0 is black, the rule looks at the colour index
+				for row := range 32 {
+					for col := 10; col < 32; col++ {
+						p.vram[0][0x1800+row*32+col] = tileSolid2
+					}
+				}
+				setSprite(p, 0, 16+10, 8+76, tileSolid1, objPriority)
+				setSprite(p, 1, 16+30, 8+76, tileSolid1, 0)
+				// A hidden priority sprite still beats a lower-priority sprite under it
+				setSprite(p, 2, 16+50, 8+84, tileSolid1, objPriority)
+				setSprite(p, 3, 16+50, 8+86, tileSolid3, 0)
+			},
+			checks: []pixelCheck{
+				{76, 10, 1, "priority sprite over BG colour 0"},
+				{83, 10, 2, "priority sprite behind BG colour 2"},
+				{83, 30, 1, "plain sprite over BG colour 2"},
+				{88, 50, 2, "hidden priority sprite owns the column"},
+				{93, 50, 3, "lower-priority sprite past the overlap"},
+				{70, 50, 3, "BG colour 0 through BGP"},
+			},
+		},
+	}
+
+	for _, scene := range scenes {
+		var frames []*image.RGBA
+		for _, r := range renderers {
+			t.Run(scene.name+"/"+r.name, func(t *testing.T) {
+				p := newSpriteScene(r.mode, scene.lcdc)
+				scene.setup(p)
+				frame := runFrame(p)
+				for _, c := range scene.checks {
+					if got := shadeAt(t, frame, c.x, c.y); got != c.shade {
+						t.Errorf("%s: pixel %d,%d = %d, want %d", c.what, c.x, c.y, got, c.shade)
+					}
+				}
+				frames = append(frames, frame)
+			})
+		}
+		if len(frames) == len(renderers) {
+			// Both renderers must produce the same golden frame
+			t.Run(scene.name+"/golden", func(t *testing.T) {
+				for _, frame := range frames {
+					checkGolden(t, scene.name, frame)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+const (
+	dividerReg = 0xFF04 // DIV
+	timaReg    = 0xFF05 // Timer counter
+	tmaReg     = 0xFF06 // Timer modulo
+	tacReg     = 0xFF07 // Timer control
+)
+
+const (
+	tacEnable         uint8 = 1 << 2
+	tacClockMask      uint8 = 0x03
+	timerReloadCycles       = 4 // TIMA reads 0 for one M-cycle before TMA is loaded
+)
+
+// tacCounterBits maps the TAC clock select to the internal counter bit whose
+// falling edge increments TIMA (4096, 262144, 65536 and 16384 Hz)
+var tacCounterBits = [4]uint{9, 3, 5, 7}
+
+// Timer is driven by the 16-bit internal counter incremented every T-cycle.
+// DIV is its upper byte and TIMA counts falling edges of the TAC-selected bit
+// ANDed with the enable bit, which is what makes DIV and TAC writes glitch.
+type Timer struct {
+	counter uint16
+	tima    uint8
+	tma     uint8
+	tac     uint8
+
+	reloadDelay  int // T-cycles until TMA is loaded after an overflow
+	reloadWindow int // T-cycles of the M-cycle in which TMA was just loaded
+
+	requestInterrupt func(mask uint8)
+}
+
+func NewTimer(requestInterrupt func(mask uint8)) *Timer {
+	return &Timer{requestInterrupt: requestInterrupt}
+}
+
+// Tick advances the timer by the given number of T-cycles
+func (t *Timer) Tick(cycles int) {
+	for range cycles {
+		if t.reloadDelay > 0 {
+			t.reloadDelay--
+			if t.reloadDelay == 0 {
+				t.tima = t.tma
+				t.requestInterrupt(TimerInterrupt)
+				t.reloadWindow = timerReloadCycles
+			}
+		} else if t.reloadWindow > 0 {
+			t.reloadWindow--
+		}
+		t.setCounter(t.counter + 1)
+	}
+}
+
+// signal is the input of the falling edge detector
+func (t *Timer) signal() bool {
+	bit := tacCounterBits[t.tac&tacClockMask]
+	return t.tac&tacEnable != 0 && t.counter>>bit&1 != 0
+}
+
+func (t *Timer) setCounter(value uint16) {
+	before := t.signal()
+	t.counter = value
+	if before && !t.signal() {
+		t.incrementTIMA()
+	}
+}
+
+func (t *Timer) incrementTIMA() {
+	t.tima++
+	if t.tima == 0 {
+		t.reloadDelay = timerReloadCycles
+	}
+}
+
+func (t *Timer) ReadRegister(addr uint16) uint8 {
+	switch addr {
+	case dividerReg:
+		return uint8(t.counter >> 8)
+	case timaReg:
+		return t.tima
+	case tmaReg:
+		return t.tma
+	case tacReg:
+		// Upper 5 bits are unused and always read as 1
+		return t.tac | 0xF8
+	}
+	return 0xFF
+}
+
+func (t *Timer) WriteRegister(addr uint16, value uint8) {
+	switch addr {
+	case dividerReg:
+		// Any write clears the whole counter, which may be a falling edge
+		t.setCounter(0)
+	case timaReg:
+		switch {
+		case t.reloadWindow > 0:
+			// Ignored, TMA is being loaded in this cycle
+		case t.reloadDelay > 0:
+			// Writing during the overflow cycle cancels the reload and the interrupt
+			t.reloadDelay = 0
+			t.tima = value
+		default:
+			t.tima = value
+		}
+	case tmaReg:
+		t.tma = value
+		if t.reloadWindow > 0 {
+			t.tima = value
+		}
+	case tacReg:
+		// Disabling the timer or switching the bit may be a falling edge
+		before := t.signal()
+		t.tac = value & (tacEnable | tacClockMask)
+		if before && !t.signal() {
+			t.incrementTIMA()
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import "testing"
+
+// timerWithIF returns a timer recording its interrupt requests in *requested
+func timerWithIF(requested *int) *Timer {
+	return NewTimer(func(mask uint8) {
+		if mask&TimerInterrupt != 0 {
+			*requested++
+		}
+	})
+}
+
+func TestTimerDIV(t *testing.T) {
+	var irq int
+	timer := timerWithIF(&irq)
+	timer.Tick(255)
+	if got := timer.ReadRegister(dividerReg); got != 0 {
+		t.Fatalf("DIV after 255 cycles = %d, want 0", got)
+	}
+	timer.Tick(1)
+	if got := timer.ReadRegister(dividerReg); got != 1 {
+		t.Fatalf("DIV after 256 cycles = %d, want 1", got)
+	}
+	timer.Tick(256 * 0xFF)
+	if got := timer.ReadRegister(dividerReg); got != 0 {
+		t.Errorf("DIV after 65536 cycles = %d, want it wrapped to 0", got)
+	}
+	timer.Tick(0x300)
+	timer.WriteRegister(dividerReg, 0x5A)
+	if got := timer.ReadRegister(dividerReg); got != 0 {
+		t.Errorf("DIV after a write = %d, want 0", got)
+	}
+}
+
+func TestTimerTIMARates(t *testing.T) {
+	tests := []struct {
+		tac    uint8
+		period int
+	}{
+		{0x04, 1024},
+		{0x05, 16},
+		{0x06, 64},
+		{0x07, 256},
+	}
+	for _, tt := range tests {
+		var irq int
+		timer := timerWithIF(&irq)
+		timer.WriteRegister(tacReg, tt.tac)
+		timer.Tick(tt.period - 1)
+		if got := timer.ReadRegister(timaReg); got != 0 {
+			t.Errorf("TAC %02X: TIMA = %d one cycle early", tt.tac, got)
+		}
+		timer.Tick(1 + 9*tt.period)
+		if got := timer.ReadRegister(timaReg); got != 10 {
+			t.Errorf("TAC %02X: TIMA = %d after 10 periods, want 10", tt.tac, got)
+		}
+	}
+
+	var irq int
+	timer := timerWithIF(&irq)
+	timer.WriteRegister(tacReg, 0x01) // Stopped
+	timer.Tick(4096)
+	if got := timer.ReadRegister(timaReg); got != 0 {
+		t.Errorf("disabled timer counted to %d", got)
+	}
+	if got := timer.ReadRegister(tacReg); got != 0xF9 {
+		t.Errorf("TAC reads %02X, want F9", got)
+	}
+}
+
+// TestTimerFallingEdgeGlitches covers writes that make the selected counter
+// bit fall outside of normal counting, which increments TIMA
+func TestTimerFallingEdgeGlitches(t *testing.T) {
+	tests := []struct {
+		name    string
+		tac     uint8  // Initial TAC
+		counter uint16 // Internal counter before the write
+		addr    uint16
+		value   uint8
+		want    uint8 // TIMA after the write
+	}{
+		{"DIV write with bit 3 high", 0x05, 0x0008, dividerReg, 0, 1},
+		{"DIV write with bit 3 low", 0x05, 0x0017, dividerReg, 0, 0},
+		{"DIV write with bit 5 high", 0x06, 0x0020, dividerReg, 0, 1},
+		{"DIV write with bit 7 high", 0x07, 0x0080, dividerReg, 0, 1},
+		{"DIV write with bit 9 high", 0x04, 0x0200, dividerReg, 0, 1},
+		{"DIV write with bit 9 low", 0x04, 0x01FF, dividerReg, 0, 0},
+		{"DIV write while disabled", 0x01, 0x0008, dividerReg, 0, 0},
+		{"TAC disable with bit high", 0x05, 0x0008, tacReg, 0x01, 1},
+		{"TAC disable with bit low", 0x05, 0x0010, tacReg, 0x01, 0},
+		{"TAC switch from a high to a low bit", 0x05, 0x0008, tacReg, 0x06, 1},
+		{"TAC switch from a low to a high bit", 0x06, 0x0008, tacReg, 0x05, 0},
+		{"TAC switch between high bits", 0x05, 0x0028, tacReg, 0x06, 0},
+		{"TAC enable with bit high", 0x01, 0x0008, tacReg, 0x05, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var irq int
+			timer := timerWithIF(&irq)
+			timer.tac = tt.tac & (tacEnable | tacClockMask)
+			timer.counter = tt.counter
+			timer.WriteRegister(tt.addr, tt.value)
+			if got := timer.ReadRegister(timaReg); got != tt.want {
+				t.Errorf("TIMA = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTimerRapidToggle turns the timer off and on with the selected bit high:
+// every disable is a falling edge, every enable is not
+func TestTimerRapidToggle(t *testing.T) {
+	var irq int
+	timer := timerWithIF(&irq)
+	timer.counter = 0x0200
+	timer.WriteRegister(timaReg, 0x10)
+	for range 4 {
+		timer.WriteRegister(tacReg, 0x04)
+		timer.WriteRegister(tacReg, 0x00)
+	}
+	if got := timer.ReadRegister(timaReg); got != 0x14 {
+		t.Errorf("TIMA = %02X after 4 toggles from 10, want 14", got)
+	}
+
+	// With the bit low, toggling does nothing
+	timer.counter = 0x0100
+	timer.WriteRegister(tacReg, 0x04)
+	timer.WriteRegister(tacReg, 0x00)
+	if got := timer.ReadRegister(timaReg); got != 0x14 {
+		t.Errorf("TIMA = %02X after a toggle with the bit low, want 14", got)
+	}
+}
+
+// TestTimerReload follows TIMA through an overflow M-cycle by M-cycle: it reads
+// 0 for one M-cycle (A), then TMA is loaded and the interrupt requested (B)
+func TestTimerReload(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(timer *Timer)
+		mcycle   int // M-cycles after the overflow at which write happens
+		wantTIMA uint8
+		wantIRQ  int
+	}{
+		{"no write", nil, 0, 0x80, 1},
+		{"TIMA write in cycle A cancels the reload", func(timer *Timer) { timer.WriteRegister(timaReg, 0x42) }, 0, 0x42, 0},
+		{"TIMA write in cycle B is ignored", func(timer *Timer) { timer.WriteRegister(timaReg, 0x42) }, 1, 0x80, 1},
+		{"TMA write in cycle A is loaded", func(timer *Timer) { timer.WriteRegister(tmaReg, 0x33) }, 0, 0x33, 1},
+		{"TMA write in cycle B also lands in TIMA", func(timer *Timer) { timer.WriteRegister(tmaReg, 0x33) }, 1, 0x33, 1},
+		{"TIMA write after the reload", func(timer *Timer) { timer.WriteRegister(timaReg, 0x42) }, 2, 0x42, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var irq int
+			timer := timerWithIF(&irq)
+			timer.WriteRegister(tmaReg, 0x80)
+			timer.WriteRegister(tacReg, 0x05)
+			timer.WriteRegister(timaReg, 0xFF)
+
+			timer.Tick(16) // Overflow at the end of this M-cycle
+			if got := timer.ReadRegister(timaReg); got != 0 {
+				t.Fatalf("TIMA during cycle A = %02X, want 00", got)
+			}
+			if irq != 0 {
+				t.Fatal("interrupt requested before the reload")
+			}
+			for m := 0; m < 3; m++ {
+				if m == tt.mcycle && tt.write != nil {
+					tt.write(timer)
+				}
+				timer.Tick(4)
+			}
+			if got := timer.ReadRegister(timaReg); got != tt.wantTIMA {
+				t.Errorf("TIMA = %02X, want %02X", got, tt.wantTIMA)
+			}
+			if irq != tt.wantIRQ {
+				t.Errorf("%d timer interrupts, want %d", irq, tt.wantIRQ)
+			}
+		})
+	}
+}
+
+// TestTimerWithinInstruction checks that timer registers are accessed at the
+// M-cycle of the access, not before or after the whole instruction. The
+// LDH (a8),A below writes in its third M-cycle.
+func TestTimerWithinInstruction(t *testing.T) {
+	tests := []struct {
+		name     string
+		reg      uint8  // LDH target
+		counter  uint16 // Internal counter when the instruction starts
+		wantTIMA uint8
+		wantIRQ  bool
+	}{
+		// The overflow happens at the end of the second M-cycle: the write lands in cycle A
+		{"TIMA write in cycle A", 0x05, 0x0008, 0x42, false},
+		// Overflow at the end of the first M-cycle: the write lands in cycle B
+		{"TIMA write in cycle B", 0x05, 0x000C, 0x10, true},
+		{"TMA write in cycle B", 0x06, 0x000C, 0x42, true},
+		// Bit 3 does not fall during the instruction, TIMA is just set
+		{"TIMA write without an overflow", 0x05, 0x0010, 0x42, false},
+		// Bit 3 falls after the write, which counts from the written value
+		{"TIMA write before an increment", 0x05, 0x0004, 0x43, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu := newTestCPU([]byte{0xE0, tt.reg}) // LDH (a8),A
+			cpu.Registers.A = 0x42
+			timer := cpu.Mmu.timer
+			timer.tac = 0x05
+			timer.tma = 0x10
+			timer.tima = 0xFF
+			timer.counter = tt.counter
+
+			cpu.Mmu.Tick(cpu.Step())
+
+			if got := timer.tima; got != tt.wantTIMA {
+				t.Errorf("TIMA = %02X, want %02X", got, tt.wantTIMA)
+			}
+			if got := cpu.Mmu.interruptFlag&TimerInterrupt != 0; got != tt.wantIRQ {
+				t.Errorf("timer interrupt requested = %v, want %v", got, tt.wantIRQ)
+			}
+			if got := timer.counter; got != tt.counter+12 {
+				t.Errorf("counter advanced to %04X, want %04X", got, tt.counter+12)
+			}
+		})
+	}
+
+	// A DIV write resets the counter at its M-cycle, the rest of the instruction still counts
+	cpu := newTestCPU([]byte{0xE0, 0x04}) // LDH (DIV),A
+	cpu.Mmu.timer.counter = 0x1234
+	cpu.Mmu.Tick(cpu.Step())
+	if got := cpu.Mmu.timer.counter; got != 4 {
+		t.Errorf("counter after a DIV write in the last M-cycle = %d, want 4", got)
+	}
+}
@@ -396,12 +396,13 @@ func determineGroupedFuncName(instr rawInstr, prefix string) string {
 			if isR8(op1) {
 				return baseName + "R8"
 			}
-			if isR16(op1) {
-				return baseName + "R16"
-			}
+			// INC (HL) must be checked before INC rr, as isR16 also matches HL
 			if isMemHL(op1) {
 				return baseName + "MemHL"
 			}
+			if isR16(op1) {
+				return baseName + "R16"
+			}
 		}
 
 	// --- Rotates/Shifts (CB prefixed or direct A) ---
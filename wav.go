@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+const (
+	wavHeaderSize    = 44
+	wavChannels      = 2
+	wavBitsPerSample = 16
+)
+
+// WAVWriter is an AudioSink recording 16-bit PCM stereo to a WAV file. The
+// RIFF sizes are patched in on Close, so the output is only complete after it.
+type WAVWriter struct {
+	f          *os.File
+	sampleRate int
+	dataBytes  uint32
+	buf        []byte
+	err        error // First write error, reported by Close
+}
+
+// CreateWAV creates path and writes a header for the given sample rate
+func CreateWAV(path string, sampleRate int) (*WAVWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := &WAVWriter{f: f, sampleRate: sampleRate}
+	if err := w.writeHeader(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *WAVWriter) writeHeader() error {
+	blockAlign := wavChannels * wavBitsPerSample / 8
+
+	var h [wavHeaderSize]byte
+	copy(h[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:8], 36+w.dataBytes)
+	copy(h[8:12], "WAVE")
+	copy(h[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:20], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(h[20:22], 1)  // PCM
+	binary.LittleEndian.PutUint16(h[22:24], wavChannels)
+	binary.LittleEndian.PutUint32(h[24:28], uint32(w.sampleRate))
+	binary.LittleEndian.PutUint32(h[28:32], uint32(w.sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(h[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(h[34:36], wavBitsPerSample)
+	copy(h[36:40], "data")
+	binary.LittleEndian.PutUint32(h[40:44], w.dataBytes)
+
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := w.f.Write(h[:])
+	return err
+}
+
+func (w *WAVWriter) WriteSamples(samples []float32) {
+	if w.err != nil {
+		return
+	}
+	w.buf = w.buf[:0]
+	for _, s := range samples {
+		v := int16(math.Round(float64(max(-1, min(1, s))) * math.MaxInt16))
+		w.buf = binary.LittleEndian.AppendUint16(w.buf, uint16(v))
+	}
+	if _, err := w.f.Write(w.buf); err != nil {
+		w.err = err
+		return
+	}
+	w.dataBytes += uint32(len(w.buf))
+}
+
+// Close finalises the header and closes the file
+func (w *WAVWriter) Close() error {
+	err := w.err
+	if err == nil {
+		err = w.writeHeader()
+	}
+	if cerr := w.f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("wav: %w", err)
+	}
+	return nil
+}